@@ -0,0 +1,317 @@
+package bctest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dan-collins/biggommerce/order"
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+// Paging defaults and maximum of the real list endpoints
+const (
+	defaultLimit = 50
+	maxLimit     = 250
+)
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, parts []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(parts) == 2 && parts[0] == "v2" && parts[1] == "order_statuses":
+		writeJSON(w, s.statuses, len(s.statuses))
+	case len(parts) == 2 && parts[0] == "v2" && parts[1] == "orders":
+		s.listOrders(w, r.URL.Query())
+	case len(parts) == 3 && parts[0] == "v2" && parts[1] == "orders" && parts[2] == "count":
+		s.countOrders(w)
+	case len(parts) >= 3 && len(parts) <= 4 && parts[0] == "v2" && parts[1] == "orders":
+		id, err := strconv.ParseInt(parts[2], 10, 64)
+		stored, ok := s.orders[id]
+		if err != nil || !ok {
+			writeError(w, http.StatusNotFound, "The requested resource was not found.")
+			return
+		}
+		if len(parts) == 3 {
+			writeJSON(w, s.withResources(stored.order), 1)
+			return
+		}
+		s.subResource(w, r.URL.Query(), stored, parts[3])
+	default:
+		writeError(w, http.StatusNotFound, "The requested resource was not found.")
+	}
+}
+
+func (s *Server) subResource(w http.ResponseWriter, q url.Values, stored *storedOrder, name string) {
+	switch name {
+	case "products":
+		page, limit, err := parsePage(q)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		start, end := pageBounds(len(stored.products), page, limit)
+		products := stored.products[start:end]
+		writeJSON(w, products, len(products))
+	case "shipping_addresses":
+		writeJSON(w, stored.addresses, len(stored.addresses))
+	case "coupons":
+		writeJSON(w, stored.coupons, len(stored.coupons))
+	case "shipments":
+		writeJSON(w, stored.shipments, len(stored.shipments))
+	default:
+		writeError(w, http.StatusNotFound, "The requested resource was not found.")
+	}
+}
+
+// withResources fills in the sub resource urls pointing back at the server
+func (s *Server) withResources(o order.Order) order.Order {
+	resource := func(name string) primative.Resource {
+		path := fmt.Sprintf("/orders/%d/%s", o.ID, name)
+		return primative.Resource{URL: s.BaseURL() + s.StoreKey + "/v2" + path, Resource: path}
+	}
+	o.ProductResource = resource("products")
+	o.ShippingResource = resource("shipping_addresses")
+	o.CouponResource = resource("coupons")
+	return o
+}
+
+func (s *Server) listOrders(w http.ResponseWriter, q url.Values) {
+	page, limit, err := parsePage(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter, err := parseFilter(q)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var matched []order.Order
+	for _, stored := range s.orders {
+		if filter.matches(stored.order) {
+			matched = append(matched, s.withResources(stored.order))
+		}
+	}
+	err = sortOrders(matched, q.Get("sort"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	start, end := pageBounds(len(matched), page, limit)
+	result := matched[start:end]
+	writeJSON(w, result, len(result))
+}
+
+// parsePage reads the page and limit params the V2 list endpoints share
+func parsePage(q url.Values) (page, limit int, err error) {
+	page, limit = 1, defaultLimit
+	if v := q.Get("page"); v != "" {
+		n, ok := atoi(v)
+		if !ok || n < 1 {
+			return 0, 0, errors.New("The field 'page' is invalid.")
+		}
+		page = n
+	}
+	if v := q.Get("limit"); v != "" {
+		n, ok := atoi(v)
+		if !ok || n < 1 {
+			return 0, 0, errors.New("The field 'limit' is invalid.")
+		}
+		if n > maxLimit {
+			n = maxLimit
+		}
+		limit = n
+	}
+	return page, limit, nil
+}
+
+// pageBounds is the slice of a list of n items that makes up the page
+func pageBounds(n, page, limit int) (start, end int) {
+	start = (page - 1) * limit
+	if start > n {
+		start = n
+	}
+	end = start + limit
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+func (s *Server) countOrders(w http.ResponseWriter) {
+	counts := map[int64]int{}
+	total := 0
+	for _, stored := range s.orders {
+		if stored.order.IsDeleted {
+			continue
+		}
+		counts[stored.order.StatusID]++
+		total++
+	}
+	data := order.OrderCount{Count: total}
+	for _, st := range s.statuses {
+		data.StatusCounts = append(data.StatusCounts, order.StatusCount{
+			StatusElement: st,
+			SortOrder:     int(st.Order),
+			Count:         counts[st.ID],
+		})
+	}
+	writeJSON(w, data, 1)
+}
+
+// orderFilter is the parsed form of the order.Query params
+type orderFilter struct {
+	minID, maxID                 int64
+	minTotal, maxTotal           *primative.Money
+	customerID                   int64
+	email, cartID, paymentMethod string
+	statusID                     *int64
+	minCreated, maxCreated       time.Time
+	minModified, maxModified     time.Time
+	isDeleted                    bool
+}
+
+func parseFilter(q url.Values) (orderFilter, error) {
+	var f orderFilter
+	var err error
+	ints := map[string]*int64{"min_id": &f.minID, "max_id": &f.maxID, "customer_id": &f.customerID}
+	for key, dst := range ints {
+		if v := q.Get(key); v != "" {
+			*dst, err = strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return f, fmt.Errorf("The field '%s' is invalid.", key)
+			}
+		}
+	}
+	if v := q.Get("status_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return f, errors.New("The field 'status_id' is invalid.")
+		}
+		f.statusID = &id
+	}
+	totals := map[string]**primative.Money{"min_total": &f.minTotal, "max_total": &f.maxTotal}
+	for key, dst := range totals {
+		if v := q.Get(key); v != "" {
+			m, err := primative.ParseMoney(v)
+			if err != nil {
+				return f, fmt.Errorf("The field '%s' is invalid.", key)
+			}
+			*dst = &m
+		}
+	}
+	dates := map[string]*time.Time{
+		"min_date_created": &f.minCreated, "max_date_created": &f.maxCreated,
+		"min_date_modified": &f.minModified, "max_date_modified": &f.maxModified,
+	}
+	for key, dst := range dates {
+		if v := q.Get(key); v != "" {
+			*dst, err = parseDate(v)
+			if err != nil {
+				return f, fmt.Errorf("The field '%s' is invalid.", key)
+			}
+		}
+	}
+	f.email = q.Get("email")
+	f.cartID = q.Get("cart_id")
+	f.paymentMethod = q.Get("payment_method")
+	f.isDeleted = q.Get("is_deleted") == "true"
+	return f, nil
+}
+
+// parseDate accepts the RFC 2822 dates order.Query sends as well as ISO 8601
+func parseDate(v string) (time.Time, error) {
+	t, err := time.Parse(time.RFC1123Z, v)
+	if err != nil {
+		t, err = time.Parse(time.RFC3339, v)
+	}
+	return t, err
+}
+
+func (f orderFilter) matches(o order.Order) bool {
+	switch {
+	case o.IsDeleted != f.isDeleted:
+	case f.minID != 0 && o.ID < f.minID:
+	case f.maxID != 0 && o.ID > f.maxID:
+	case f.minTotal != nil && o.TotalIncTax.LessThan(*f.minTotal):
+	case f.maxTotal != nil && o.TotalIncTax.GreaterThan(*f.maxTotal):
+	case f.customerID != 0 && o.CustomerID != f.customerID:
+	case f.email != "" && !strings.EqualFold(o.BillingAddress.Email, f.email):
+	case f.statusID != nil && o.StatusID != *f.statusID:
+	case f.cartID != "" && o.CartID != f.cartID:
+	case f.paymentMethod != "" && o.PaymentMethod != f.paymentMethod:
+	case !f.minCreated.IsZero() && o.DateCreated.Before(f.minCreated):
+	case !f.maxCreated.IsZero() && o.DateCreated.After(f.maxCreated):
+	case !f.minModified.IsZero() && o.DateModified.Before(f.minModified):
+	case !f.maxModified.IsZero() && o.DateModified.After(f.maxModified):
+	default:
+		return true
+	}
+	return false
+}
+
+// sortOrders sorts by a "field:direction" sort param, ties and the default are by id ascending
+func sortOrders(orders []order.Order, sortBy string) error {
+	field, dir := sortBy, "asc"
+	if i := strings.IndexByte(sortBy, ':'); i >= 0 {
+		field, dir = sortBy[:i], strings.ToLower(sortBy[i+1:])
+	}
+	var compare func(a, b order.Order) int
+	switch field {
+	case "", "id":
+		compare = func(a, b order.Order) int { return compareInt(a.ID, b.ID) }
+	case "date_created":
+		compare = func(a, b order.Order) int { return compareTime(a.DateCreated.Time, b.DateCreated.Time) }
+	case "date_modified":
+		compare = func(a, b order.Order) int { return compareTime(a.DateModified.Time, b.DateModified.Time) }
+	case "status_id":
+		compare = func(a, b order.Order) int { return compareInt(a.StatusID, b.StatusID) }
+	case "customer_id":
+		compare = func(a, b order.Order) int { return compareInt(a.CustomerID, b.CustomerID) }
+	default:
+		return errors.New("The field 'sort' is invalid.")
+	}
+	if dir != "asc" && dir != "desc" {
+		return errors.New("The field 'sort' is invalid.")
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		c := compare(orders[i], orders[j])
+		if dir == "desc" {
+			c = -c
+		}
+		if c == 0 {
+			return orders[i].ID < orders[j].ID
+		}
+		return c < 0
+	})
+	return nil
+}
+
+func compareInt(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
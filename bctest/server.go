@@ -0,0 +1,289 @@
+// Package bctest provides an in-memory fake of the BigCommerce V2 order endpoints for testing code built on the
+// order package without a live store.
+//
+//	srv := bctest.NewServer()
+//	defer srv.Close()
+//	srv.AddOrder(order.Order{StatusID: 11, Products: []order.OrderProduct{{Name: "Mug", Quantity: 1}}})
+//	orders, err := srv.Client().GetHydratedOrders(order.Query{StatusID: 11})
+package bctest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dan-collins/biggommerce/connect"
+	"github.com/dan-collins/biggommerce/order"
+)
+
+// Credentials the fake server expects unless they are changed before the first request
+const (
+	DefaultAuthToken  = "test-token"
+	DefaultAuthClient = "test-client"
+	DefaultStoreKey   = "test-store"
+)
+
+// Fault makes the server fail requests instead of answering them
+type Fault struct {
+	// Path limits the fault to requests whose path contains it, empty matches every request
+	Path string
+	// Status is the status code returned, e.g. 429 or 500
+	Status int
+	// Times is how many matching requests fail before the fault is used up, 0 fails them forever
+	Times int
+	// Header is added to the failed response, a 429 without X-Rate-Limit-Time-Reset-Ms gets a 1ms reset
+	Header http.Header
+}
+
+// Server is an httptest.Server that behaves like the V2 orders API of a single store. It is safe for concurrent use
+type Server struct {
+	*httptest.Server
+	AuthToken string
+	StoreKey  string
+
+	mu       sync.Mutex
+	orders   map[int64]*storedOrder
+	nextID   int64
+	statuses order.Statuses
+	faults   []*Fault
+	latency  time.Duration
+	requests []string
+}
+
+// storedOrder keeps the sub resources of an order apart from the order itself, as the real API does
+type storedOrder struct {
+	order     order.Order
+	products  []order.OrderProduct
+	addresses []order.ShippingAddress
+	coupons   []order.Coupon
+	shipments []order.Shipment
+}
+
+// NewServer starts a fake store with the default credentials, no orders and the standard BigCommerce order statuses.
+// Close it when done
+func NewServer() *Server {
+	s := &Server{
+		AuthToken: DefaultAuthToken,
+		StoreKey:  DefaultStoreKey,
+		orders:    map[int64]*storedOrder{},
+		nextID:    100,
+		statuses:  defaultStatuses(),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL is the base url to hand to BCClient.SetBaseURL
+func (s *Server) BaseURL() string {
+	return s.URL + "/stores/"
+}
+
+// Client returns an order client pointed at the server. It has its own rate limiter and retries quickly, so faults
+// can be exercised without slowing tests down
+func (s *Server) Client() *order.Client {
+	c := order.NewClient(s.AuthToken, DefaultAuthClient, s.StoreKey)
+	c.SetBaseURL(s.BaseURL())
+	c.Limiter = connect.NewRateLimiter(10000, time.Second)
+	c.Retry.BaseDelay = time.Millisecond
+	c.Retry.MaxDelay = 10 * time.Millisecond
+	return c
+}
+
+// AddOrder stores an order and returns its id. The Products, ShippingAddresses, Coupons and Shipments of o are
+// served from the order's sub resource endpoints rather than on the order itself. An id is assigned when o.ID is 0
+func (s *Server) AddOrder(o order.Order) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if o.ID == 0 {
+		s.nextID++
+		o.ID = s.nextID
+	} else if o.ID > s.nextID {
+		s.nextID = o.ID
+	}
+	if o.DateCreated.IsZero() {
+		o.DateCreated.Time = time.Now().Truncate(time.Second)
+	}
+	if o.DateModified.IsZero() {
+		o.DateModified = o.DateCreated
+	}
+	stored := &storedOrder{
+		products:  o.Products,
+		addresses: o.ShippingAddresses,
+		coupons:   o.Coupons,
+		shipments: o.Shipments,
+	}
+	for i := range stored.products {
+		stored.products[i].OrderID = o.ID
+	}
+	for i := range stored.coupons {
+		stored.coupons[i].OrderID = o.ID
+	}
+	for i := range stored.shipments {
+		stored.shipments[i].OrderID = o.ID
+	}
+	o.Products, o.ShippingAddresses, o.Coupons, o.Shipments, o.Transactions = nil, nil, nil, nil, nil
+	o.ItemsTotal = 0
+	for _, p := range stored.products {
+		o.ItemsTotal += p.Quantity
+	}
+	o.ShippingAddressCount = int64(len(stored.addresses))
+	if o.Status == "" {
+		o.Status = s.statusName(o.StatusID)
+	}
+	stored.order = o
+	s.orders[o.ID] = stored
+	return o.ID
+}
+
+// SetStatuses replaces the order statuses the store has
+func (s *Server) SetStatuses(statuses order.Statuses) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses = statuses
+}
+
+// InjectFault adds a fault, faults are checked in the order they were added
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// SetLatency delays every response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Requests returns the method and path with query of every request the server received, in order
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+	latency := s.latency
+	fault := s.matchFault(r.URL.Path)
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if fault != nil {
+		writeFault(w, fault)
+		return
+	}
+	if r.Header.Get("X-Auth-Token") != s.AuthToken {
+		writeError(w, http.StatusUnauthorized, "You have not provided a valid X-Auth-Token header")
+		return
+	}
+	prefix := "/stores/" + s.StoreKey + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeError(w, http.StatusNotFound, "The requested store could not be found")
+		return
+	}
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "The fake store only supports GET requests")
+		return
+	}
+	s.route(w, r, strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/"))
+}
+
+// matchFault returns a copy of the first live fault for path and uses one of its lives, the caller must hold mu
+func (s *Server) matchFault(path string) *Fault {
+	for i, f := range s.faults {
+		if f.Path != "" && !strings.Contains(path, f.Path) {
+			continue
+		}
+		matched := *f
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return &matched
+	}
+	return nil
+}
+
+func writeFault(w http.ResponseWriter, f *Fault) {
+	for k, vs := range f.Header {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	if f.Status == http.StatusTooManyRequests && w.Header().Get("X-Rate-Limit-Time-Reset-Ms") == "" {
+		w.Header().Set("X-Rate-Limit-Time-Reset-Ms", "1")
+		w.Header().Set("X-Rate-Limit-Requests-Left", "0")
+	}
+	writeError(w, f.Status, http.StatusText(f.Status))
+}
+
+// writeError writes the V2 error array BigCommerce uses
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode([]connect.ErrorMessage{{Status: status, Message: message}})
+}
+
+// writeJSON writes v, an empty list is a 204 with no body like the real API
+func writeJSON(w http.ResponseWriter, v interface{}, length int) {
+	if length == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) statusName(id int64) string {
+	for _, st := range s.statuses {
+		if st.ID == id {
+			return st.Name
+		}
+	}
+	return ""
+}
+
+func defaultStatuses() order.Statuses {
+	names := []string{
+		"Incomplete", "Pending", "Shipped", "Partially Shipped", "Refunded", "Cancelled", "Declined",
+		"Awaiting Payment", "Awaiting Pickup", "Awaiting Shipment", "Completed", "Awaiting Fulfillment",
+		"Manual Verification Required", "Disputed", "Partially Refunded",
+	}
+	statuses := make(order.Statuses, 0, len(names))
+	for i, name := range names {
+		statuses = append(statuses, order.StatusElement{
+			ID:          int64(i),
+			Name:        name,
+			CustomLabel: name,
+			SystemLabel: name,
+			Order:       int64(i),
+		})
+	}
+	return statuses
+}
+
+func atoi(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
@@ -0,0 +1,187 @@
+// Package catalog reads and writes the products of a store's catalog through the V3 catalog API
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/dan-collins/biggommerce/connect"
+)
+
+// Client is a wrapper struct that embeds the BCClient from the client package. It handles connection to the BigCommerce API
+type Client struct {
+	connect.BCClient
+}
+
+// NewClient will create a new catalog client wrapper based on BC connection details, products are read 250 to a page
+func NewClient(authToken, authClient, storeKey string) *Client {
+	bcClient := connect.NewClient(authToken, authClient, storeKey)
+	catalogClient := Client{}
+	catalogClient.BCClient = *bcClient
+	catalogClient.Limit = 250
+	return &catalogClient
+}
+
+// productQuery is the raw query of pq with the client's Limit when pq has none
+func (s *Client) productQuery(pq ProductQuery) (string, error) {
+	if pq.Limit == 0 {
+		pq.Limit = s.Limit
+	}
+	return pq.GetRawQuery()
+}
+
+// GetProducts will return the products matching pq, every page is read unless pq.Page is set
+func (s *Client) GetProducts(pq ProductQuery) ([]Product, error) {
+	return s.GetProductsContext(context.Background(), pq)
+}
+
+// GetProductsContext - same as GetProducts, cancelling ctx stops fetching any further pages
+func (s *Client) GetProductsContext(ctx context.Context, pq ProductQuery) ([]Product, error) {
+	rawQuery, err := s.productQuery(pq)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]Product, 0)
+	if pq.Page != 0 {
+		_, err = s.GetV3AndUnmarshalContext(ctx, "v3/catalog/products", rawQuery, &data)
+	} else {
+		err = s.GetAllPagesContext(ctx, "v3/catalog/products", rawQuery, &data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// WalkProducts will call fn with every product matching pq one page at a time, so a large catalog never has to be
+// held in memory at once. Returning an error from fn stops the walk
+func (s *Client) WalkProducts(pq ProductQuery, fn func(p Product) error) error {
+	return s.WalkProductsContext(context.Background(), pq, fn)
+}
+
+// WalkProductsContext - same as WalkProducts, cancelling ctx stops fetching any further pages
+func (s *Client) WalkProductsContext(ctx context.Context, pq ProductQuery, fn func(p Product) error) error {
+	rawQuery, err := s.productQuery(pq)
+	if err != nil {
+		return err
+	}
+	return s.WalkPagesContext(ctx, "v3/catalog/products", rawQuery, func(data json.RawMessage, p connect.Pagination) error {
+		var page []Product
+		err := json.Unmarshal(data, &page)
+		if err != nil {
+			return err
+		}
+		for _, product := range page {
+			err = fn(product)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetProduct will return a single product along with the sub resources in include, e.g. IncludeVariants
+func (s *Client) GetProduct(productID int, include ...string) (*Product, error) {
+	return s.GetProductContext(context.Background(), productID, include...)
+}
+
+// GetProductContext - same as GetProduct but the request is cancelled when ctx is done
+func (s *Client) GetProductContext(ctx context.Context, productID int, include ...string) (*Product, error) {
+	var rawQuery string
+	if len(include) > 0 {
+		rawQuery = "include=" + strings.Join(include, ",")
+	}
+	var data Product
+	_, err := s.GetV3AndUnmarshalContext(ctx, fmt.Sprintf("v3/catalog/products/%d", productID), rawQuery, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CreateProduct will validate and create a new product, returning the product as BigCommerce saved it
+func (s *Client) CreateProduct(p ProductCreate) (*Product, error) {
+	return s.CreateProductContext(context.Background(), p)
+}
+
+// CreateProductContext - same as CreateProduct but the request is cancelled when ctx is done
+func (s *Client) CreateProductContext(ctx context.Context, p ProductCreate) (*Product, error) {
+	err := p.Validate()
+	if err != nil {
+		return nil, err
+	}
+	var data Product
+	_, err = s.DoV3Context(ctx, "POST", "v3/catalog/products", "", p, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateProduct will validate and apply the fields set in patch to the product, returning the updated product
+func (s *Client) UpdateProduct(productID int, patch ProductUpdate) (*Product, error) {
+	return s.UpdateProductContext(context.Background(), productID, patch)
+}
+
+// UpdateProductContext - same as UpdateProduct but the request is cancelled when ctx is done
+func (s *Client) UpdateProductContext(ctx context.Context, productID int, patch ProductUpdate) (*Product, error) {
+	err := patch.Validate()
+	if err != nil {
+		return nil, err
+	}
+	// the id is only part of the body in batch updates
+	patch.ID = 0
+	var data Product
+	_, err = s.DoV3Context(ctx, "PUT", fmt.Sprintf("v3/catalog/products/%d", productID), "", patch, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateProducts will validate and apply every patch to the product its ID points at, MaxBatchSize products per
+// request, and return the updated products. The batches are sent one after the other and the first failing one
+// stops the rest, the products of the batches before it stay updated and are returned along with the error
+func (s *Client) UpdateProducts(patches []ProductUpdate) ([]Product, error) {
+	return s.UpdateProductsContext(context.Background(), patches)
+}
+
+// UpdateProductsContext - same as UpdateProducts, cancelling ctx stops sending any further batches
+func (s *Client) UpdateProductsContext(ctx context.Context, patches []ProductUpdate) ([]Product, error) {
+	for i, patch := range patches {
+		if patch.ID == 0 {
+			return nil, fmt.Errorf("catalog: patches[%d]: id is required", i)
+		}
+		if err := patch.validate(); err != nil {
+			return nil, fmt.Errorf("catalog: patches[%d]: %w", i, err)
+		}
+	}
+	updated := make([]Product, 0, len(patches))
+	for start := 0; start < len(patches); start += MaxBatchSize {
+		end := start + MaxBatchSize
+		if end > len(patches) {
+			end = len(patches)
+		}
+		var data []Product
+		_, err := s.DoV3Context(ctx, "PUT", "v3/catalog/products", "", patches[start:end], &data)
+		if err != nil {
+			return updated, err
+		}
+		updated = append(updated, data...)
+	}
+	return updated, nil
+}
+
+// DeleteProduct will delete the product along with its variants, options and images
+func (s *Client) DeleteProduct(productID int) error {
+	return s.DeleteProductContext(context.Background(), productID)
+}
+
+// DeleteProductContext - same as DeleteProduct but the request is cancelled when ctx is done
+func (s *Client) DeleteProductContext(ctx context.Context, productID int) error {
+	_, err := s.DoV3Context(ctx, "DELETE", fmt.Sprintf("v3/catalog/products/%d", productID), "", nil, nil)
+	return err
+}
@@ -0,0 +1,122 @@
+package catalog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dan-collins/biggommerce/connect"
+)
+
+// newBatchServer returns a client whose batch updates to the v3 catalog resource go to a server echoing each patch
+// back, the batch numbered failBatch (from 1) fails with a 422. It also returns the size of every batch received
+func newBatchServer(t *testing.T, resource string, failBatch int) (*Client, func() []int) {
+	t.Helper()
+	var mu sync.Mutex
+	var sizes []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/store/v3/catalog/"+resource {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var patches []map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&patches); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		sizes = append(sizes, len(patches))
+		batch := len(sizes)
+		mu.Unlock()
+		if batch == failBatch {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"status":422,"title":"The name is a duplicate"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": patches})
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient("token", "client", "store")
+	c.SetBaseURL(srv.URL + "/")
+	c.Limiter = nil
+	return c, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]int(nil), sizes...)
+	}
+}
+
+func namePatches(n int) []ProductUpdate {
+	patches := make([]ProductUpdate, n)
+	for i := range patches {
+		name := fmt.Sprintf("Product %d", i+1)
+		patches[i] = ProductUpdate{ID: int64(i + 1), Name: &name}
+	}
+	return patches
+}
+
+func TestUpdateProductsBatches(t *testing.T) {
+	c, sizes := newBatchServer(t, "products", 0)
+
+	products, err := c.UpdateProducts(namePatches(23))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(sizes()); got != "[10 10 3]" {
+		t.Errorf("sent batches of %s, want [10 10 3]", got)
+	}
+	if len(products) != 23 || products[22].ID != 23 || products[22].Name != "Product 23" {
+		t.Errorf("got %d products, want all 23 in order", len(products))
+	}
+}
+
+func TestUpdateProductsStopsAtFailedBatch(t *testing.T) {
+	c, sizes := newBatchServer(t, "products", 2)
+
+	products, err := c.UpdateProducts(namePatches(23))
+	var apiErr *connect.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("err = %v, want the 422 of the second batch", err)
+	}
+	if got := fmt.Sprint(sizes()); got != "[10 10]" {
+		t.Errorf("sent batches of %s, want the third not to be sent", got)
+	}
+	if len(products) != 10 || products[0].ID != 1 || products[9].ID != 10 {
+		t.Errorf("got %d products, want the 10 of the first batch", len(products))
+	}
+}
+
+func TestUpdateProductsValidatesFirst(t *testing.T) {
+	c, sizes := newBatchServer(t, "products", 0)
+	patches := namePatches(15)
+	patches[12].ID = 0
+
+	_, err := c.UpdateProducts(patches)
+	if err == nil || len(sizes()) != 0 {
+		t.Errorf("a patch without an id should fail before any batch is sent, got %v after %v", err, sizes())
+	}
+}
+
+func TestProductQueryDates(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	raw, err := ProductQuery{MinDateModified: modified, MaxDateLastImported: modified, Sku: "MUG"}.GetRawQuery()
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := url.ParseQuery(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Get("date_modified:min") != "2024-03-01T12:30:00Z" || q.Get("date_last_imported:max") != "2024-03-01T12:30:00Z" {
+		t.Errorf("dates were sent as %s", raw)
+	}
+	if _, ok := q["date_modified:max"]; ok || q.Get("sku") != "MUG" {
+		t.Errorf("unset dates should be left out and other filters kept, got %s", raw)
+	}
+}
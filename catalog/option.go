@@ -0,0 +1,292 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Option and modifier types
+const (
+	OptionTypeRadioButtons          = "radio_buttons"
+	OptionTypeRectangles            = "rectangles"
+	OptionTypeDropdown              = "dropdown"
+	OptionTypeProductList           = "product_list"
+	OptionTypeProductListWithImages = "product_list_with_images"
+	OptionTypeSwatch                = "swatch"
+	OptionTypeDate                  = "date"
+	OptionTypeCheckbox              = "checkbox"
+	OptionTypeFile                  = "file"
+	OptionTypeText                  = "text"
+	OptionTypeMultiLineText         = "multi_line_text"
+	OptionTypeNumbersOnlyText       = "numbers_only_text"
+)
+
+// OptionWrite is the body to create or update a variant option. Creating needs DisplayName, Type and the
+// OptionValues, only the fields that are set are sent on update
+type OptionWrite struct {
+	DisplayName  string             `json:"display_name,omitempty"`
+	Type         string             `json:"type,omitempty"`
+	Config       json.RawMessage    `json:"config,omitempty"`
+	SortOrder    *int64             `json:"sort_order,omitempty"`
+	OptionValues []OptionValueWrite `json:"option_values,omitempty"`
+}
+
+// OptionValueWrite is the body to create or update a value of an option or modifier, ID is only needed when updating
+// the values through OptionWrite or ModifierWrite
+type OptionValueWrite struct {
+	ID        int64           `json:"id,omitempty"`
+	Label     string          `json:"label,omitempty"`
+	SortOrder *int64          `json:"sort_order,omitempty"`
+	ValueData json.RawMessage `json:"value_data,omitempty"`
+	IsDefault *bool           `json:"is_default,omitempty"`
+	Adjusters *Adjusters      `json:"adjusters,omitempty"`
+}
+
+// ModifierWrite is the body to create or update a modifier. Creating needs DisplayName, Type and Required, only the
+// fields that are set are sent on update
+type ModifierWrite struct {
+	DisplayName  string             `json:"display_name,omitempty"`
+	Type         string             `json:"type,omitempty"`
+	Required     *bool              `json:"required,omitempty"`
+	Config       json.RawMessage    `json:"config,omitempty"`
+	SortOrder    *int64             `json:"sort_order,omitempty"`
+	OptionValues []OptionValueWrite `json:"option_values,omitempty"`
+}
+
+// GetOptions will return the variant options of the product
+func (s *Client) GetOptions(productID int) ([]Option, error) {
+	return s.GetOptionsContext(context.Background(), productID)
+}
+
+// GetOptionsContext - same as GetOptions, cancelling ctx stops fetching any further pages
+func (s *Client) GetOptionsContext(ctx context.Context, productID int) ([]Option, error) {
+	data := make([]Option, 0)
+	err := s.GetAllPagesContext(ctx, fmt.Sprintf("v3/catalog/products/%d/options", productID), "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetOption will return a single variant option of the product
+func (s *Client) GetOption(productID int, optionID int) (*Option, error) {
+	return s.GetOptionContext(context.Background(), productID, optionID)
+}
+
+// GetOptionContext - same as GetOption but the request is cancelled when ctx is done
+func (s *Client) GetOptionContext(ctx context.Context, productID int, optionID int) (*Option, error) {
+	var data Option
+	url := fmt.Sprintf("v3/catalog/products/%d/options/%d", productID, optionID)
+	_, err := s.GetV3AndUnmarshalContext(ctx, url, "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CreateOption will add a variant option to the product. BigCommerce does not create the variants for the new
+// combinations, see CreateVariant
+func (s *Client) CreateOption(productID int, o OptionWrite) (*Option, error) {
+	return s.CreateOptionContext(context.Background(), productID, o)
+}
+
+// CreateOptionContext - same as CreateOption but the request is cancelled when ctx is done
+func (s *Client) CreateOptionContext(ctx context.Context, productID int, o OptionWrite) (*Option, error) {
+	if o.DisplayName == "" || o.Type == "" {
+		return nil, fmt.Errorf("catalog: option display_name and type are required")
+	}
+	var data Option
+	url := fmt.Sprintf("v3/catalog/products/%d/options", productID)
+	_, err := s.DoV3Context(ctx, "POST", url, "", o, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateOption will apply the fields set in o to the variant option
+func (s *Client) UpdateOption(productID int, optionID int, o OptionWrite) (*Option, error) {
+	return s.UpdateOptionContext(context.Background(), productID, optionID, o)
+}
+
+// UpdateOptionContext - same as UpdateOption but the request is cancelled when ctx is done
+func (s *Client) UpdateOptionContext(ctx context.Context, productID int, optionID int, o OptionWrite) (*Option, error) {
+	var data Option
+	url := fmt.Sprintf("v3/catalog/products/%d/options/%d", productID, optionID)
+	_, err := s.DoV3Context(ctx, "PUT", url, "", o, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteOption will delete the variant option, along with the variants that use it
+func (s *Client) DeleteOption(productID int, optionID int) error {
+	return s.DeleteOptionContext(context.Background(), productID, optionID)
+}
+
+// DeleteOptionContext - same as DeleteOption but the request is cancelled when ctx is done
+func (s *Client) DeleteOptionContext(ctx context.Context, productID int, optionID int) error {
+	url := fmt.Sprintf("v3/catalog/products/%d/options/%d", productID, optionID)
+	_, err := s.DoV3Context(ctx, "DELETE", url, "", nil, nil)
+	return err
+}
+
+// GetOptionValues will return the values of the variant option
+func (s *Client) GetOptionValues(productID int, optionID int) ([]OptionValue, error) {
+	return s.GetOptionValuesContext(context.Background(), productID, optionID)
+}
+
+// GetOptionValuesContext - same as GetOptionValues, cancelling ctx stops fetching any further pages
+func (s *Client) GetOptionValuesContext(ctx context.Context, productID int, optionID int) ([]OptionValue, error) {
+	data := make([]OptionValue, 0)
+	url := fmt.Sprintf("v3/catalog/products/%d/options/%d/values", productID, optionID)
+	err := s.GetAllPagesContext(ctx, url, "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetOptionValue will return a single value of the variant option
+func (s *Client) GetOptionValue(productID int, optionID int, valueID int) (*OptionValue, error) {
+	return s.GetOptionValueContext(context.Background(), productID, optionID, valueID)
+}
+
+// GetOptionValueContext - same as GetOptionValue but the request is cancelled when ctx is done
+func (s *Client) GetOptionValueContext(ctx context.Context, productID int, optionID int, valueID int) (*OptionValue, error) {
+	var data OptionValue
+	url := fmt.Sprintf("v3/catalog/products/%d/options/%d/values/%d", productID, optionID, valueID)
+	_, err := s.GetV3AndUnmarshalContext(ctx, url, "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CreateOptionValue will add a value to the variant option, Label is required
+func (s *Client) CreateOptionValue(productID int, optionID int, v OptionValueWrite) (*OptionValue, error) {
+	return s.CreateOptionValueContext(context.Background(), productID, optionID, v)
+}
+
+// CreateOptionValueContext - same as CreateOptionValue but the request is cancelled when ctx is done
+func (s *Client) CreateOptionValueContext(ctx context.Context, productID int, optionID int, v OptionValueWrite) (*OptionValue, error) {
+	if v.Label == "" {
+		return nil, fmt.Errorf("catalog: option value label is required")
+	}
+	var data OptionValue
+	url := fmt.Sprintf("v3/catalog/products/%d/options/%d/values", productID, optionID)
+	_, err := s.DoV3Context(ctx, "POST", url, "", v, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateOptionValue will apply the fields set in v to the value of the variant option
+func (s *Client) UpdateOptionValue(productID int, optionID int, valueID int, v OptionValueWrite) (*OptionValue, error) {
+	return s.UpdateOptionValueContext(context.Background(), productID, optionID, valueID, v)
+}
+
+// UpdateOptionValueContext - same as UpdateOptionValue but the request is cancelled when ctx is done
+func (s *Client) UpdateOptionValueContext(ctx context.Context, productID int, optionID int, valueID int, v OptionValueWrite) (*OptionValue, error) {
+	v.ID = 0
+	var data OptionValue
+	url := fmt.Sprintf("v3/catalog/products/%d/options/%d/values/%d", productID, optionID, valueID)
+	_, err := s.DoV3Context(ctx, "PUT", url, "", v, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteOptionValue will delete the value of the variant option, along with the variants that use it
+func (s *Client) DeleteOptionValue(productID int, optionID int, valueID int) error {
+	return s.DeleteOptionValueContext(context.Background(), productID, optionID, valueID)
+}
+
+// DeleteOptionValueContext - same as DeleteOptionValue but the request is cancelled when ctx is done
+func (s *Client) DeleteOptionValueContext(ctx context.Context, productID int, optionID int, valueID int) error {
+	url := fmt.Sprintf("v3/catalog/products/%d/options/%d/values/%d", productID, optionID, valueID)
+	_, err := s.DoV3Context(ctx, "DELETE", url, "", nil, nil)
+	return err
+}
+
+// GetModifiers will return the modifiers of the product
+func (s *Client) GetModifiers(productID int) ([]Modifier, error) {
+	return s.GetModifiersContext(context.Background(), productID)
+}
+
+// GetModifiersContext - same as GetModifiers, cancelling ctx stops fetching any further pages
+func (s *Client) GetModifiersContext(ctx context.Context, productID int) ([]Modifier, error) {
+	data := make([]Modifier, 0)
+	err := s.GetAllPagesContext(ctx, fmt.Sprintf("v3/catalog/products/%d/modifiers", productID), "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetModifier will return a single modifier of the product
+func (s *Client) GetModifier(productID int, modifierID int) (*Modifier, error) {
+	return s.GetModifierContext(context.Background(), productID, modifierID)
+}
+
+// GetModifierContext - same as GetModifier but the request is cancelled when ctx is done
+func (s *Client) GetModifierContext(ctx context.Context, productID int, modifierID int) (*Modifier, error) {
+	var data Modifier
+	url := fmt.Sprintf("v3/catalog/products/%d/modifiers/%d", productID, modifierID)
+	_, err := s.GetV3AndUnmarshalContext(ctx, url, "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CreateModifier will add a modifier to the product
+func (s *Client) CreateModifier(productID int, m ModifierWrite) (*Modifier, error) {
+	return s.CreateModifierContext(context.Background(), productID, m)
+}
+
+// CreateModifierContext - same as CreateModifier but the request is cancelled when ctx is done
+func (s *Client) CreateModifierContext(ctx context.Context, productID int, m ModifierWrite) (*Modifier, error) {
+	if m.DisplayName == "" || m.Type == "" || m.Required == nil {
+		return nil, fmt.Errorf("catalog: modifier display_name, type and required are required")
+	}
+	var data Modifier
+	url := fmt.Sprintf("v3/catalog/products/%d/modifiers", productID)
+	_, err := s.DoV3Context(ctx, "POST", url, "", m, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateModifier will apply the fields set in m to the modifier
+func (s *Client) UpdateModifier(productID int, modifierID int, m ModifierWrite) (*Modifier, error) {
+	return s.UpdateModifierContext(context.Background(), productID, modifierID, m)
+}
+
+// UpdateModifierContext - same as UpdateModifier but the request is cancelled when ctx is done
+func (s *Client) UpdateModifierContext(ctx context.Context, productID int, modifierID int, m ModifierWrite) (*Modifier, error) {
+	var data Modifier
+	url := fmt.Sprintf("v3/catalog/products/%d/modifiers/%d", productID, modifierID)
+	_, err := s.DoV3Context(ctx, "PUT", url, "", m, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteModifier will delete the modifier from the product
+func (s *Client) DeleteModifier(productID int, modifierID int) error {
+	return s.DeleteModifierContext(context.Background(), productID, modifierID)
+}
+
+// DeleteModifierContext - same as DeleteModifier but the request is cancelled when ctx is done
+func (s *Client) DeleteModifierContext(ctx context.Context, productID int, modifierID int) error {
+	url := fmt.Sprintf("v3/catalog/products/%d/modifiers/%d", productID, modifierID)
+	_, err := s.DoV3Context(ctx, "DELETE", url, "", nil, nil)
+	return err
+}
@@ -0,0 +1,190 @@
+package catalog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newResourceServer returns a client talking to a store that keeps whatever is posted to a collection, giving it the
+// next id, and merges the fields put to it into what it kept. Paths ending in a number are items, others collections
+func newResourceServer(t *testing.T) *Client {
+	t.Helper()
+	var mu sync.Mutex
+	nextID := 0
+	items := map[string]map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/store/")
+		var body map[string]interface{}
+		if r.Method == "POST" || r.Method == "PUT" {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("%s %s: %v", r.Method, path, err)
+			}
+		}
+		reply := func(data interface{}) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+		}
+		_, err := strconv.Atoi(path[strings.LastIndex(path, "/")+1:])
+		isItem := err == nil
+		item, found := items[path]
+		switch {
+		case r.Method == "POST" && !isItem:
+			nextID++
+			body["id"] = nextID
+			items[path+"/"+strconv.Itoa(nextID)] = body
+			reply(body)
+		case r.Method == "GET" && !isItem:
+			var list []map[string]interface{}
+			var keys []string
+			for key := range items {
+				if rest := strings.TrimPrefix(key, path+"/"); rest != key && !strings.Contains(rest, "/") {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				list = append(list, items[key])
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": list, "meta": map[string]interface{}{
+				"pagination": map[string]int{"total": len(list), "count": len(list), "current_page": 1, "total_pages": 1},
+			}})
+		case !found:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"status":404,"title":"not found"}`))
+		case r.Method == "PUT":
+			for k, v := range body {
+				item[k] = v
+			}
+			reply(item)
+		case r.Method == "GET":
+			reply(item)
+		case r.Method == "DELETE":
+			delete(items, path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	c := NewClient("token", "client", "store")
+	c.SetBaseURL(srv.URL + "/")
+	c.Limiter = nil
+	return c
+}
+
+func TestOptionRoundTrip(t *testing.T) {
+	c := newResourceServer(t)
+	one := int64(1)
+
+	created, err := c.CreateOption(7, OptionWrite{
+		DisplayName:  "Size",
+		Type:         OptionTypeRadioButtons,
+		OptionValues: []OptionValueWrite{{Label: "Small", SortOrder: &one}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == 0 || created.DisplayName != "Size" || len(created.OptionValues) != 1 || created.OptionValues[0].Label != "Small" {
+		t.Fatalf("created %+v", created)
+	}
+	if _, err := c.CreateOption(7, OptionWrite{DisplayName: "Size"}); err == nil {
+		t.Error("an option without a type should not be created")
+	}
+
+	updated, err := c.UpdateOption(7, int(created.ID), OptionWrite{DisplayName: "Mug size"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.DisplayName != "Mug size" || updated.Type != OptionTypeRadioButtons {
+		t.Errorf("updated to %+v, want only the display name changed", updated)
+	}
+	options, err := c.GetOptions(7)
+	if err != nil || len(options) != 1 || options[0].DisplayName != "Mug size" {
+		t.Fatalf("GetOptions = %+v, %v", options, err)
+	}
+
+	if err := c.DeleteOption(7, int(created.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetOption(7, int(created.ID)); err == nil {
+		t.Error("a deleted option should not be found")
+	}
+}
+
+func TestOptionValueRoundTrip(t *testing.T) {
+	c := newResourceServer(t)
+	isDefault := true
+
+	created, err := c.CreateOptionValue(7, 3, OptionValueWrite{Label: "Red", ValueData: json.RawMessage(`{"colors":["#ff0000"]}`)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Label != "Red" || string(created.ValueData) != `{"colors":["#ff0000"]}` {
+		t.Fatalf("created %+v", created)
+	}
+	updated, err := c.UpdateOptionValue(7, 3, int(created.ID), OptionValueWrite{IsDefault: &isDefault})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated.IsDefault || updated.Label != "Red" {
+		t.Errorf("updated to %+v, want the default with its label kept", updated)
+	}
+	values, err := c.GetOptionValues(7, 3)
+	if err != nil || len(values) != 1 {
+		t.Fatalf("GetOptionValues = %+v, %v", values, err)
+	}
+	if err := c.DeleteOptionValue(7, 3, int(created.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if values, _ := c.GetOptionValues(7, 3); len(values) != 0 {
+		t.Errorf("%d values left after deleting the only one", len(values))
+	}
+}
+
+func TestModifierRoundTrip(t *testing.T) {
+	c := newResourceServer(t)
+	required := false
+
+	if _, err := c.CreateModifier(7, ModifierWrite{DisplayName: "Gift message", Type: OptionTypeText}); err == nil {
+		t.Error("a modifier without required should not be created")
+	}
+	created, err := c.CreateModifier(7, ModifierWrite{
+		DisplayName: "Gift wrap",
+		Type:        OptionTypeCheckbox,
+		Required:    &required,
+		OptionValues: []OptionValueWrite{{
+			Label:     "Yes",
+			Adjusters: &Adjusters{Price: &Adjuster{Adjuster: "relative", AdjusterValue: 2.5}},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Required || len(created.OptionValues) != 1 || created.OptionValues[0].Adjusters.Price.AdjusterValue != 2.5 {
+		t.Fatalf("created %+v", created)
+	}
+
+	got, err := c.GetModifier(7, int(created.ID))
+	if err != nil || got.DisplayName != "Gift wrap" {
+		t.Fatalf("GetModifier = %+v, %v", got, err)
+	}
+	required = true
+	updated, err := c.UpdateModifier(7, int(created.ID), ModifierWrite{Required: &required})
+	if err != nil || !updated.Required || updated.DisplayName != "Gift wrap" {
+		t.Errorf("UpdateModifier = %+v, %v, want only required changed", updated, err)
+	}
+	if err := c.DeleteModifier(7, int(created.ID)); err != nil {
+		t.Fatal(err)
+	}
+	if modifiers, err := c.GetModifiers(7); err != nil || len(modifiers) != 0 {
+		t.Errorf("GetModifiers = %v, %v after deleting the only one", modifiers, err)
+	}
+	if err := c.DeleteModifier(7, int(created.ID)); err == nil {
+		t.Errorf("deleting modifier %d twice should fail", created.ID)
+	}
+}
@@ -0,0 +1,297 @@
+package catalog
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dan-collins/biggommerce/primative"
+	"github.com/google/go-querystring/query"
+)
+
+// Product types
+const (
+	ProductTypePhysical = "physical"
+	ProductTypeDigital  = "digital"
+)
+
+// Inventory tracking of a product, by the product itself or by each of its variants
+const (
+	InventoryTrackingNone    = "none"
+	InventoryTrackingProduct = "product"
+	InventoryTrackingVariant = "variant"
+)
+
+// Sub resources that can be included with products, see ProductQuery.Include
+const (
+	IncludeVariants     = "variants"
+	IncludeImages       = "images"
+	IncludeCustomFields = "custom_fields"
+	IncludeModifiers    = "modifiers"
+	IncludeOptions      = "options"
+)
+
+// Product is a product of the store's catalog, the sub resources are only filled in when they were included
+type Product struct {
+	ID                      int64           `json:"id"`
+	Name                    string          `json:"name"`
+	Type                    string          `json:"type"`
+	Sku                     string          `json:"sku"`
+	Description             string          `json:"description"`
+	Weight                  float64         `json:"weight"`
+	Width                   float64         `json:"width"`
+	Depth                   float64         `json:"depth"`
+	Height                  float64         `json:"height"`
+	Price                   primative.Money `json:"price"`
+	CostPrice               primative.Money `json:"cost_price"`
+	RetailPrice             primative.Money `json:"retail_price"`
+	SalePrice               primative.Money `json:"sale_price"`
+	MapPrice                primative.Money `json:"map_price"`
+	CalculatedPrice         primative.Money `json:"calculated_price"`
+	TaxClassID              int64           `json:"tax_class_id"`
+	ProductTaxCode          string          `json:"product_tax_code"`
+	Categories              []int64         `json:"categories"`
+	BrandID                 int64           `json:"brand_id"`
+	OptionSetID             int64           `json:"option_set_id"`
+	OptionSetDisplay        string          `json:"option_set_display"`
+	InventoryLevel          int64           `json:"inventory_level"`
+	InventoryWarningLevel   int64           `json:"inventory_warning_level"`
+	InventoryTracking       string          `json:"inventory_tracking"`
+	ReviewsRatingSum        int64           `json:"reviews_rating_sum"`
+	ReviewsCount            int64           `json:"reviews_count"`
+	TotalSold               int64           `json:"total_sold"`
+	FixedCostShippingPrice  primative.Money `json:"fixed_cost_shipping_price"`
+	IsFreeShipping          bool            `json:"is_free_shipping"`
+	IsVisible               bool            `json:"is_visible"`
+	IsFeatured              bool            `json:"is_featured"`
+	RelatedProducts         []int64         `json:"related_products"`
+	Warranty                string          `json:"warranty"`
+	BinPickingNumber        string          `json:"bin_picking_number"`
+	LayoutFile              string          `json:"layout_file"`
+	Upc                     string          `json:"upc"`
+	Mpn                     string          `json:"mpn"`
+	Gtin                    string          `json:"gtin"`
+	SearchKeywords          string          `json:"search_keywords"`
+	Availability            string          `json:"availability"`
+	AvailabilityDescription string          `json:"availability_description"`
+	GiftWrappingOptionsType string          `json:"gift_wrapping_options_type"`
+	GiftWrappingOptionsList []int64         `json:"gift_wrapping_options_list"`
+	SortOrder               int64           `json:"sort_order"`
+	Condition               string          `json:"condition"`
+	IsConditionShown        bool            `json:"is_condition_shown"`
+	OrderQuantityMinimum    int64           `json:"order_quantity_minimum"`
+	OrderQuantityMaximum    int64           `json:"order_quantity_maximum"`
+	PageTitle               string          `json:"page_title"`
+	MetaKeywords            []string        `json:"meta_keywords"`
+	MetaDescription         string          `json:"meta_description"`
+	DateCreated             time.Time       `json:"date_created"`
+	DateModified            time.Time       `json:"date_modified"`
+	ViewCount               int64           `json:"view_count"`
+	PreorderReleaseDate     *time.Time      `json:"preorder_release_date"`
+	PreorderMessage         string          `json:"preorder_message"`
+	IsPreorderOnly          bool            `json:"is_preorder_only"`
+	IsPriceHidden           bool            `json:"is_price_hidden"`
+	PriceHiddenLabel        string          `json:"price_hidden_label"`
+	CustomURL               CustomURL       `json:"custom_url"`
+	BaseVariantID           int64           `json:"base_variant_id"`
+	Variants                []Variant       `json:"variants,omitempty"`
+	Images                  []Image         `json:"images,omitempty"`
+	CustomFields            []CustomField   `json:"custom_fields,omitempty"`
+	Modifiers               []Modifier      `json:"modifiers,omitempty"`
+	Options                 []Option        `json:"options,omitempty"`
+}
+
+// CustomURL is the storefront path of a product
+type CustomURL struct {
+	URL          string `json:"url"`
+	IsCustomized bool   `json:"is_customized"`
+}
+
+// Image is a product image, ImageURL is only used to add an image from a url
+type Image struct {
+	ID           int64     `json:"id,omitempty"`
+	ProductID    int64     `json:"product_id,omitempty"`
+	IsThumbnail  bool      `json:"is_thumbnail"`
+	SortOrder    int64     `json:"sort_order"`
+	Description  string    `json:"description"`
+	ImageFile    string    `json:"image_file,omitempty"`
+	ImageURL     string    `json:"image_url,omitempty"`
+	URLZoom      string    `json:"url_zoom,omitempty"`
+	URLStandard  string    `json:"url_standard,omitempty"`
+	URLThumbnail string    `json:"url_thumbnail,omitempty"`
+	URLTiny      string    `json:"url_tiny,omitempty"`
+	DateModified time.Time `json:"date_modified"`
+}
+
+// CustomField is a name and value shown with a product on the storefront
+type CustomField struct {
+	ID    int64  `json:"id,omitempty"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Variant is a purchasable combination of a product's option values, with its own sku and stock. The prices and
+// weight are null when the variant uses the product's
+type Variant struct {
+	ID                        int64                `json:"id"`
+	ProductID                 int64                `json:"product_id"`
+	Sku                       string               `json:"sku"`
+	SkuID                     int64                `json:"sku_id"`
+	Price                     *primative.Money     `json:"price"`
+	CalculatedPrice           primative.Money      `json:"calculated_price"`
+	SalePrice                 *primative.Money     `json:"sale_price"`
+	RetailPrice               *primative.Money     `json:"retail_price"`
+	MapPrice                  *primative.Money     `json:"map_price"`
+	CostPrice                 *primative.Money     `json:"cost_price"`
+	Weight                    *float64             `json:"weight"`
+	CalculatedWeight          float64              `json:"calculated_weight"`
+	Width                     *float64             `json:"width"`
+	Height                    *float64             `json:"height"`
+	Depth                     *float64             `json:"depth"`
+	IsFreeShipping            bool                 `json:"is_free_shipping"`
+	FixedCostShippingPrice    *primative.Money     `json:"fixed_cost_shipping_price"`
+	PurchasingDisabled        bool                 `json:"purchasing_disabled"`
+	PurchasingDisabledMessage string               `json:"purchasing_disabled_message"`
+	ImageURL                  string               `json:"image_url"`
+	Upc                       string               `json:"upc"`
+	Mpn                       string               `json:"mpn"`
+	Gtin                      string               `json:"gtin"`
+	InventoryLevel            int64                `json:"inventory_level"`
+	InventoryWarningLevel     int64                `json:"inventory_warning_level"`
+	BinPickingNumber          string               `json:"bin_picking_number"`
+	OptionValues              []VariantOptionValue `json:"option_values"`
+}
+
+// VariantOptionValue is the value of one option that makes up a variant
+type VariantOptionValue struct {
+	ID                int64  `json:"id"`
+	Label             string `json:"label"`
+	OptionID          int64  `json:"option_id"`
+	OptionDisplayName string `json:"option_display_name"`
+}
+
+// Option is a variant option of a product, e.g. size or colour, every combination of its values is a Variant
+type Option struct {
+	ID           int64           `json:"id"`
+	ProductID    int64           `json:"product_id"`
+	Name         string          `json:"name"`
+	DisplayName  string          `json:"display_name"`
+	Type         string          `json:"type"`
+	Config       json.RawMessage `json:"config,omitempty"`
+	SortOrder    int64           `json:"sort_order"`
+	OptionValues []OptionValue   `json:"option_values"`
+}
+
+// OptionValue is a value an option or modifier can take, ValueData holds e.g. the colours of a swatch
+type OptionValue struct {
+	ID        int64           `json:"id"`
+	OptionID  int64           `json:"option_id,omitempty"`
+	Label     string          `json:"label"`
+	SortOrder int64           `json:"sort_order"`
+	ValueData json.RawMessage `json:"value_data,omitempty"`
+	IsDefault bool            `json:"is_default"`
+	Adjusters *Adjusters      `json:"adjusters,omitempty"`
+}
+
+// Modifier is a product option that does not make up a variant, e.g. a gift message, it can adjust the price or
+// weight of the line it is chosen on
+type Modifier struct {
+	ID           int64           `json:"id"`
+	ProductID    int64           `json:"product_id"`
+	Name         string          `json:"name"`
+	DisplayName  string          `json:"display_name"`
+	Type         string          `json:"type"`
+	Required     bool            `json:"required"`
+	Config       json.RawMessage `json:"config,omitempty"`
+	SortOrder    int64           `json:"sort_order"`
+	OptionValues []OptionValue   `json:"option_values"`
+}
+
+// Adjusters is what choosing a modifier value changes on the line
+type Adjusters struct {
+	Price              *Adjuster          `json:"price"`
+	Weight             *Adjuster          `json:"weight"`
+	ImageURL           string             `json:"image_url"`
+	PurchasingDisabled PurchasingDisabled `json:"purchasing_disabled"`
+}
+
+// Adjuster changes a price or weight, Adjuster is "relative" or "percentage"
+type Adjuster struct {
+	Adjuster      string  `json:"adjuster"`
+	AdjusterValue float64 `json:"adjuster_value"`
+}
+
+// PurchasingDisabled stops a product from being bought while a modifier value is chosen
+type PurchasingDisabled struct {
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+}
+
+// ProductQuery filters the products GetProducts returns
+type ProductQuery struct {
+	ID                  int       `url:"id,omitempty"`
+	IDs                 []int     `url:"id:in,comma,omitempty"`
+	NotIDs              []int     `url:"id:not_in,comma,omitempty"`
+	MinID               int       `url:"id:min,omitempty"`
+	MaxID               int       `url:"id:max,omitempty"`
+	Name                string    `url:"name,omitempty"`
+	NameLike            string    `url:"name:like,omitempty"`
+	Sku                 string    `url:"sku,omitempty"`
+	Skus                []string  `url:"sku:in,comma,omitempty"`
+	Upc                 string    `url:"upc,omitempty"`
+	Price               float64   `url:"price,omitempty"`
+	MinPrice            float64   `url:"price:min,omitempty"`
+	MaxPrice            float64   `url:"price:max,omitempty"`
+	Weight              float64   `url:"weight,omitempty"`
+	Condition           string    `url:"condition,omitempty"`
+	BrandID             int       `url:"brand_id,omitempty"`
+	Type                string    `url:"type,omitempty"`
+	Categories          []int     `url:"categories:in,comma,omitempty"`
+	Keyword             string    `url:"keyword,omitempty"`
+	KeywordContext      string    `url:"keyword_context,omitempty"`
+	Availability        string    `url:"availability,omitempty"`
+	IsVisible           *bool     `url:"is_visible,omitempty"`
+	IsFeatured          *bool     `url:"is_featured,omitempty"`
+	IsFreeShipping      *bool     `url:"is_free_shipping,omitempty"`
+	InventoryLevel      *int      `url:"inventory_level,omitempty"`
+	MinInventoryLevel   *int      `url:"inventory_level:min,omitempty"`
+	MaxInventoryLevel   *int      `url:"inventory_level:max,omitempty"`
+	InventoryLow        *int      `url:"inventory_low,omitempty"`
+	OutOfStock          *int      `url:"out_of_stock,omitempty"`
+	TotalSold           *int      `url:"total_sold,omitempty"`
+	PriceListID         int       `url:"price_list_id,omitempty"`
+	MinDateModified     time.Time `url:"-"`
+	MaxDateModified     time.Time `url:"-"`
+	MinDateLastImported time.Time `url:"-"`
+	MaxDateLastImported time.Time `url:"-"`
+	// Include is the sub resources to load with every product, e.g. IncludeVariants and IncludeImages
+	Include       []string `url:"include,comma,omitempty"`
+	IncludeFields []string `url:"include_fields,comma,omitempty"`
+	ExcludeFields []string `url:"exclude_fields,comma,omitempty"`
+	Page          int      `url:"page,omitempty"`
+	Limit         int      `url:"limit,omitempty"`
+	Sort          string   `url:"sort,omitempty"`
+	Direction     string   `url:"direction,omitempty"`
+}
+
+// GetRawQuery gets the struct in query string form
+func (q ProductQuery) GetRawQuery() (string, error) {
+	v, err := query.Values(q)
+	if err != nil {
+		return "", err
+	}
+	dates := []struct {
+		key string
+		t   time.Time
+	}{
+		{"date_modified:min", q.MinDateModified},
+		{"date_modified:max", q.MaxDateModified},
+		{"date_last_imported:min", q.MinDateLastImported},
+		{"date_last_imported:max", q.MaxDateLastImported},
+	}
+	for _, d := range dates {
+		if !d.t.IsZero() {
+			v.Set(d.key, d.t.Format(time.RFC3339))
+		}
+	}
+	return v.Encode(), nil
+}
@@ -0,0 +1,360 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/dan-collins/biggommerce/order"
+	"github.com/dan-collins/biggommerce/primative"
+	"github.com/google/go-querystring/query"
+)
+
+// VariantQuery filters the variants GetAllVariants returns across the store
+type VariantQuery struct {
+	ID            int      `url:"id,omitempty"`
+	IDs           []int    `url:"id:in,comma,omitempty"`
+	Sku           string   `url:"sku,omitempty"`
+	Skus          []string `url:"sku:in,comma,omitempty"`
+	ProductIDs    []int    `url:"product_id:in,comma,omitempty"`
+	IncludeFields []string `url:"include_fields,comma,omitempty"`
+	ExcludeFields []string `url:"exclude_fields,comma,omitempty"`
+	Page          int      `url:"page,omitempty"`
+	Limit         int      `url:"limit,omitempty"`
+}
+
+// GetRawQuery gets the struct in query string form
+func (q VariantQuery) GetRawQuery() (string, error) {
+	v, err := query.Values(q)
+	if err != nil {
+		return "", err
+	}
+	return v.Encode(), nil
+}
+
+// VariantCreate is the body of BigCommerce POST /catalog/products/{id}/variants. Sku is required and OptionValues
+// has to pick one value of every variant option of the product
+type VariantCreate struct {
+	Sku                       string                    `json:"sku"`
+	OptionValues              []VariantOptionValueWrite `json:"option_values"`
+	Price                     *primative.Money          `json:"price,omitempty"`
+	SalePrice                 *primative.Money          `json:"sale_price,omitempty"`
+	RetailPrice               *primative.Money          `json:"retail_price,omitempty"`
+	MapPrice                  *primative.Money          `json:"map_price,omitempty"`
+	CostPrice                 *primative.Money          `json:"cost_price,omitempty"`
+	Weight                    *float64                  `json:"weight,omitempty"`
+	Width                     *float64                  `json:"width,omitempty"`
+	Height                    *float64                  `json:"height,omitempty"`
+	Depth                     *float64                  `json:"depth,omitempty"`
+	IsFreeShipping            bool                      `json:"is_free_shipping,omitempty"`
+	FixedCostShippingPrice    *primative.Money          `json:"fixed_cost_shipping_price,omitempty"`
+	PurchasingDisabled        bool                      `json:"purchasing_disabled,omitempty"`
+	PurchasingDisabledMessage string                    `json:"purchasing_disabled_message,omitempty"`
+	ImageURL                  string                    `json:"image_url,omitempty"`
+	Upc                       string                    `json:"upc,omitempty"`
+	Mpn                       string                    `json:"mpn,omitempty"`
+	Gtin                      string                    `json:"gtin,omitempty"`
+	InventoryLevel            int64                     `json:"inventory_level,omitempty"`
+	InventoryWarningLevel     int64                     `json:"inventory_warning_level,omitempty"`
+	BinPickingNumber          string                    `json:"bin_picking_number,omitempty"`
+}
+
+// VariantOptionValueWrite picks the value (ID) of one option (OptionID) for a new variant
+type VariantOptionValueWrite struct {
+	ID       int64 `json:"id"`
+	OptionID int64 `json:"option_id"`
+}
+
+// VariantUpdate is the body of BigCommerce PUT /catalog/products/{id}/variants/{id}, only the fields that are set
+// are sent so it can be used as a patch. ID is only needed for UpdateVariants, where it says which variant each
+// patch is for
+type VariantUpdate struct {
+	ID                        int64            `json:"id,omitempty"`
+	Sku                       *string          `json:"sku,omitempty"`
+	Price                     *primative.Money `json:"price,omitempty"`
+	SalePrice                 *primative.Money `json:"sale_price,omitempty"`
+	RetailPrice               *primative.Money `json:"retail_price,omitempty"`
+	MapPrice                  *primative.Money `json:"map_price,omitempty"`
+	CostPrice                 *primative.Money `json:"cost_price,omitempty"`
+	Weight                    *float64         `json:"weight,omitempty"`
+	Width                     *float64         `json:"width,omitempty"`
+	Height                    *float64         `json:"height,omitempty"`
+	Depth                     *float64         `json:"depth,omitempty"`
+	IsFreeShipping            *bool            `json:"is_free_shipping,omitempty"`
+	FixedCostShippingPrice    *primative.Money `json:"fixed_cost_shipping_price,omitempty"`
+	PurchasingDisabled        *bool            `json:"purchasing_disabled,omitempty"`
+	PurchasingDisabledMessage *string          `json:"purchasing_disabled_message,omitempty"`
+	ImageURL                  *string          `json:"image_url,omitempty"`
+	Upc                       *string          `json:"upc,omitempty"`
+	Mpn                       *string          `json:"mpn,omitempty"`
+	Gtin                      *string          `json:"gtin,omitempty"`
+	InventoryLevel            *int64           `json:"inventory_level,omitempty"`
+	InventoryWarningLevel     *int64           `json:"inventory_warning_level,omitempty"`
+	BinPickingNumber          *string          `json:"bin_picking_number,omitempty"`
+}
+
+// Validate checks the fields BigCommerce requires to create a variant are filled in
+func (v VariantCreate) Validate() error {
+	if v.Sku == "" {
+		return errors.New("catalog: variant sku is required")
+	}
+	if len(v.OptionValues) == 0 {
+		return errors.New("catalog: variant needs at least one option value")
+	}
+	for i, ov := range v.OptionValues {
+		if ov.ID == 0 || ov.OptionID == 0 {
+			return fmt.Errorf("catalog: variant option_values[%d]: id and option_id are required", i)
+		}
+	}
+	return nil
+}
+
+// Validate checks the parts of the patch that are set make sense
+func (v VariantUpdate) Validate() error {
+	if err := v.validate(); err != nil {
+		return fmt.Errorf("catalog: %w", err)
+	}
+	return nil
+}
+
+func (v VariantUpdate) validate() error {
+	if v.Sku != nil && *v.Sku == "" {
+		return errors.New("variant sku can not be emptied")
+	}
+	if v.Price != nil && v.Price.Sign() < 0 {
+		return errors.New("variant price can not be negative")
+	}
+	if v.InventoryLevel != nil && *v.InventoryLevel < 0 {
+		return errors.New("variant inventory_level can not be negative")
+	}
+	return nil
+}
+
+// GetVariants will return every variant of the product
+func (s *Client) GetVariants(productID int) ([]Variant, error) {
+	return s.GetVariantsContext(context.Background(), productID)
+}
+
+// GetVariantsContext - same as GetVariants, cancelling ctx stops fetching any further pages
+func (s *Client) GetVariantsContext(ctx context.Context, productID int) ([]Variant, error) {
+	data := make([]Variant, 0)
+	url := fmt.Sprintf("v3/catalog/products/%d/variants", productID)
+	err := s.GetAllPagesContext(ctx, url, fmt.Sprintf("limit=%d", s.Limit), &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetVariant will return a single variant of the product
+func (s *Client) GetVariant(productID int, variantID int) (*Variant, error) {
+	return s.GetVariantContext(context.Background(), productID, variantID)
+}
+
+// GetVariantContext - same as GetVariant but the request is cancelled when ctx is done
+func (s *Client) GetVariantContext(ctx context.Context, productID int, variantID int) (*Variant, error) {
+	var data Variant
+	url := fmt.Sprintf("v3/catalog/products/%d/variants/%d", productID, variantID)
+	_, err := s.GetV3AndUnmarshalContext(ctx, url, "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CreateVariant will validate and add a variant to the product, returning the variant as BigCommerce saved it
+func (s *Client) CreateVariant(productID int, v VariantCreate) (*Variant, error) {
+	return s.CreateVariantContext(context.Background(), productID, v)
+}
+
+// CreateVariantContext - same as CreateVariant but the request is cancelled when ctx is done
+func (s *Client) CreateVariantContext(ctx context.Context, productID int, v VariantCreate) (*Variant, error) {
+	err := v.Validate()
+	if err != nil {
+		return nil, err
+	}
+	var data Variant
+	url := fmt.Sprintf("v3/catalog/products/%d/variants", productID)
+	_, err = s.DoV3Context(ctx, "POST", url, "", v, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateVariant will validate and apply the fields set in patch to the variant, returning the updated variant
+func (s *Client) UpdateVariant(productID int, variantID int, patch VariantUpdate) (*Variant, error) {
+	return s.UpdateVariantContext(context.Background(), productID, variantID, patch)
+}
+
+// UpdateVariantContext - same as UpdateVariant but the request is cancelled when ctx is done
+func (s *Client) UpdateVariantContext(ctx context.Context, productID int, variantID int, patch VariantUpdate) (*Variant, error) {
+	err := patch.Validate()
+	if err != nil {
+		return nil, err
+	}
+	// the id is only part of the body in batch updates
+	patch.ID = 0
+	var data Variant
+	url := fmt.Sprintf("v3/catalog/products/%d/variants/%d", productID, variantID)
+	_, err = s.DoV3Context(ctx, "PUT", url, "", patch, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteVariant will delete the variant from the product
+func (s *Client) DeleteVariant(productID int, variantID int) error {
+	return s.DeleteVariantContext(context.Background(), productID, variantID)
+}
+
+// DeleteVariantContext - same as DeleteVariant but the request is cancelled when ctx is done
+func (s *Client) DeleteVariantContext(ctx context.Context, productID int, variantID int) error {
+	url := fmt.Sprintf("v3/catalog/products/%d/variants/%d", productID, variantID)
+	_, err := s.DoV3Context(ctx, "DELETE", url, "", nil, nil)
+	return err
+}
+
+// GetAllVariants will return the variants of every product matching vq, every page is read unless vq.Page is set
+func (s *Client) GetAllVariants(vq VariantQuery) ([]Variant, error) {
+	return s.GetAllVariantsContext(context.Background(), vq)
+}
+
+// GetAllVariantsContext - same as GetAllVariants, cancelling ctx stops fetching any further pages
+func (s *Client) GetAllVariantsContext(ctx context.Context, vq VariantQuery) ([]Variant, error) {
+	if vq.Limit == 0 {
+		vq.Limit = s.Limit
+	}
+	rawQuery, err := vq.GetRawQuery()
+	if err != nil {
+		return nil, err
+	}
+	data := make([]Variant, 0)
+	if vq.Page != 0 {
+		_, err = s.GetV3AndUnmarshalContext(ctx, "v3/catalog/variants", rawQuery, &data)
+	} else {
+		err = s.GetAllPagesContext(ctx, "v3/catalog/variants", rawQuery, &data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// UpdateVariants will validate and apply every patch to the variant its ID points at, whichever product it belongs
+// to, MaxBatchSize variants per request. Like UpdateProducts the first failing batch stops the rest and the variants
+// updated before it are returned along with the error
+func (s *Client) UpdateVariants(patches []VariantUpdate) ([]Variant, error) {
+	return s.UpdateVariantsContext(context.Background(), patches)
+}
+
+// UpdateVariantsContext - same as UpdateVariants, cancelling ctx stops sending any further batches
+func (s *Client) UpdateVariantsContext(ctx context.Context, patches []VariantUpdate) ([]Variant, error) {
+	for i, patch := range patches {
+		if patch.ID == 0 {
+			return nil, fmt.Errorf("catalog: patches[%d]: id is required", i)
+		}
+		if err := patch.validate(); err != nil {
+			return nil, fmt.Errorf("catalog: patches[%d]: %w", i, err)
+		}
+	}
+	updated := make([]Variant, 0, len(patches))
+	for start := 0; start < len(patches); start += MaxBatchSize {
+		end := start + MaxBatchSize
+		if end > len(patches) {
+			end = len(patches)
+		}
+		var data []Variant
+		_, err := s.DoV3Context(ctx, "PUT", "v3/catalog/variants", "", patches[start:end], &data)
+		if err != nil {
+			return updated, err
+		}
+		updated = append(updated, data...)
+	}
+	return updated, nil
+}
+
+// GetVariantForOrderProduct will fetch the variants of the order product's catalog product and return the one it was
+// bought as, see VariantForOrderProduct
+func (s *Client) GetVariantForOrderProduct(op order.OrderProduct) (*Variant, error) {
+	return s.GetVariantForOrderProductContext(context.Background(), op)
+}
+
+// GetVariantForOrderProductContext - same as GetVariantForOrderProduct but the requests are cancelled when ctx is done
+func (s *Client) GetVariantForOrderProductContext(ctx context.Context, op order.OrderProduct) (*Variant, error) {
+	if op.ProductID == 0 {
+		return nil, fmt.Errorf("catalog: order product %d is a custom product, it has no variants", op.ID)
+	}
+	variants, err := s.GetVariantsContext(ctx, int(op.ProductID))
+	if err != nil {
+		return nil, err
+	}
+	return VariantForOrderProduct(op, variants)
+}
+
+// VariantForOrderProduct picks the variant op was bought as out of the variants of its product. The variant id of
+// the order line is used when BigCommerce sent one and it is still among variants, otherwise the variant whose
+// option values are exactly the ones chosen in op.ProductOptions, a product_option_id and the value id, is picked,
+// falling back to the variant with op.Sku. Product options that are modifiers rather than variant options are
+// ignored. A product without options has a single variant, which is always the one
+func VariantForOrderProduct(op order.OrderProduct, variants []Variant) (*Variant, error) {
+	if op.VariantID != 0 {
+		for i := range variants {
+			if variants[i].ID == op.VariantID {
+				return &variants[i], nil
+			}
+		}
+		// the variant was deleted, or recreated under a new id, since the order was placed
+	}
+	if len(variants) == 1 && len(variants[0].OptionValues) == 0 {
+		return &variants[0], nil
+	}
+
+	chosen := map[int64]int64{}
+	for _, po := range op.ProductOptions {
+		valueID, err := strconv.ParseInt(po.Value, 10, 64)
+		if err != nil {
+			// free text and other non choice options can not make up a variant
+			continue
+		}
+		chosen[po.ProductOptionID] = valueID
+	}
+	var match *Variant
+	for i := range variants {
+		if !variantChosen(variants[i], chosen) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("catalog: order product %d matches more than one variant", op.ID)
+		}
+		match = &variants[i]
+	}
+	if match == nil && op.Sku != "" {
+		// the options can be gone from the order line when the product was edited after the order, the sku is the
+		// next best thing
+		for i := range variants {
+			if variants[i].Sku == op.Sku {
+				return &variants[i], nil
+			}
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("catalog: no variant of product %d matches the options of order product %d", op.ProductID, op.ID)
+	}
+	return match, nil
+}
+
+// variantChosen reports whether every option value of v is the one chosen for its option
+func variantChosen(v Variant, chosen map[int64]int64) bool {
+	if len(v.OptionValues) == 0 {
+		return false
+	}
+	for _, ov := range v.OptionValues {
+		if valueID, ok := chosen[ov.OptionID]; !ok || valueID != ov.ID {
+			return false
+		}
+	}
+	return true
+}
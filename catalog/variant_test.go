@@ -0,0 +1,119 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/dan-collins/biggommerce/connect"
+
+	"github.com/dan-collins/biggommerce/order"
+)
+
+func TestVariantForOrderProduct(t *testing.T) {
+	mugs := []Variant{
+		{ID: 11, Sku: "MUG-S-RED", OptionValues: []VariantOptionValue{{ID: 101, OptionID: 1}, {ID: 201, OptionID: 2}}},
+		{ID: 12, Sku: "MUG-L-RED", OptionValues: []VariantOptionValue{{ID: 102, OptionID: 1}, {ID: 201, OptionID: 2}}},
+		{ID: 13, Sku: "MUG-L-BLUE", OptionValues: []VariantOptionValue{{ID: 102, OptionID: 1}, {ID: 202, OptionID: 2}}},
+	}
+	smallRed := []order.ProductOption{{ProductOptionID: 1, Value: "101"}, {ProductOptionID: 2, Value: "201"}}
+	withModifiers := append([]order.ProductOption{
+		{ProductOptionID: 9, Value: "901"},
+		{ProductOptionID: 8, Value: "Happy birthday"},
+	}, smallRed...)
+	duplicated := append(append([]Variant(nil), mugs...),
+		Variant{ID: 14, Sku: "MUG-S-RED-2", OptionValues: []VariantOptionValue{{ID: 101, OptionID: 1}, {ID: 201, OptionID: 2}}})
+
+	tests := []struct {
+		name     string
+		op       order.OrderProduct
+		variants []Variant
+		want     int64
+	}{
+		{"variant id", order.OrderProduct{VariantID: 13, ProductOptions: smallRed}, mugs, 13},
+		{"variant id gone falls back to options", order.OrderProduct{VariantID: 99, ProductOptions: smallRed}, mugs, 11},
+		{"options", order.OrderProduct{ProductOptions: smallRed}, mugs, 11},
+		{"modifiers ignored", order.OrderProduct{ProductOptions: withModifiers}, mugs, 11},
+		{"ambiguous options", order.OrderProduct{ProductOptions: smallRed}, duplicated, 0},
+		{"sku when the options are gone", order.OrderProduct{Sku: "MUG-L-BLUE"}, mugs, 13},
+		{"sku when the options changed", order.OrderProduct{VariantID: 99, Sku: "MUG-L-RED", ProductOptions: []order.ProductOption{{ProductOptionID: 1, Value: "103"}}}, mugs, 12},
+		{"single base variant", order.OrderProduct{VariantID: 99}, []Variant{{ID: 5, Sku: "PLAIN"}}, 5},
+		{"partial options", order.OrderProduct{ProductOptions: smallRed[:1]}, mugs, 0},
+		{"nothing matches", order.OrderProduct{VariantID: 99, Sku: "OTHER"}, mugs, 0},
+	}
+	for _, tt := range tests {
+		tt.op.ID, tt.op.ProductID = 1, 7
+		got, err := VariantForOrderProduct(tt.op, tt.variants)
+		if tt.want == 0 {
+			if err == nil {
+				t.Errorf("%s: picked variant %d, want an error", tt.name, got.ID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got.ID != tt.want {
+			t.Errorf("%s: picked variant %d, want %d", tt.name, got.ID, tt.want)
+		}
+	}
+}
+
+func skuPatches(n int) []VariantUpdate {
+	patches := make([]VariantUpdate, n)
+	for i := range patches {
+		sku := fmt.Sprintf("SKU-%d", i+1)
+		patches[i] = VariantUpdate{ID: int64(i + 1), Sku: &sku}
+	}
+	return patches
+}
+
+func TestUpdateVariantsBatches(t *testing.T) {
+	c, sizes := newBatchServer(t, "variants", 0)
+
+	variants, err := c.UpdateVariants(skuPatches(23))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(sizes()); got != "[10 10 3]" {
+		t.Errorf("sent batches of %s, want [10 10 3]", got)
+	}
+	if len(variants) != 23 || variants[22].ID != 23 || variants[22].Sku != "SKU-23" {
+		t.Errorf("got %d variants, want all 23 in order", len(variants))
+	}
+}
+
+func TestUpdateVariantsStopsAtFailedBatch(t *testing.T) {
+	c, sizes := newBatchServer(t, "variants", 2)
+
+	variants, err := c.UpdateVariants(skuPatches(23))
+	var apiErr *connect.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("err = %v, want the 422 of the second batch", err)
+	}
+	if got := fmt.Sprint(sizes()); got != "[10 10]" {
+		t.Errorf("sent batches of %s, want the third not to be sent", got)
+	}
+	if len(variants) != 10 || variants[9].ID != 10 {
+		t.Errorf("got %d variants, want the 10 of the first batch", len(variants))
+	}
+}
+
+func TestUpdateVariantsValidatesFirst(t *testing.T) {
+	c, sizes := newBatchServer(t, "variants", 0)
+	patches := skuPatches(15)
+	empty := ""
+	patches[12].Sku = &empty
+
+	_, err := c.UpdateVariants(patches)
+	if err == nil || len(sizes()) != 0 {
+		t.Errorf("an invalid patch should fail before any batch is sent, got %v after %v", err, sizes())
+	}
+	patches = skuPatches(3)
+	patches[1].ID = 0
+	if _, err := c.UpdateVariants(patches); err == nil || len(sizes()) != 0 {
+		t.Errorf("a patch without an id should fail before any batch is sent, got %v", err)
+	}
+}
@@ -0,0 +1,158 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+// MaxBatchSize is the most products (or variants) BigCommerce updates in a single batch request
+const MaxBatchSize = 10
+
+// ProductCreate is the body of BigCommerce POST /catalog/products.
+//
+// Name, Type, Weight and Price are required, use Validate to check them before sending
+type ProductCreate struct {
+	Name                    string           `json:"name"`
+	Type                    string           `json:"type"`
+	Weight                  float64          `json:"weight"`
+	Price                   primative.Money  `json:"price"`
+	Sku                     string           `json:"sku,omitempty"`
+	Description             string           `json:"description,omitempty"`
+	Width                   float64          `json:"width,omitempty"`
+	Depth                   float64          `json:"depth,omitempty"`
+	Height                  float64          `json:"height,omitempty"`
+	CostPrice               *primative.Money `json:"cost_price,omitempty"`
+	RetailPrice             *primative.Money `json:"retail_price,omitempty"`
+	SalePrice               *primative.Money `json:"sale_price,omitempty"`
+	MapPrice                *primative.Money `json:"map_price,omitempty"`
+	TaxClassID              int64            `json:"tax_class_id,omitempty"`
+	ProductTaxCode          string           `json:"product_tax_code,omitempty"`
+	Categories              []int64          `json:"categories,omitempty"`
+	BrandID                 int64            `json:"brand_id,omitempty"`
+	InventoryLevel          int64            `json:"inventory_level,omitempty"`
+	InventoryWarningLevel   int64            `json:"inventory_warning_level,omitempty"`
+	InventoryTracking       string           `json:"inventory_tracking,omitempty"`
+	FixedCostShippingPrice  *primative.Money `json:"fixed_cost_shipping_price,omitempty"`
+	IsFreeShipping          bool             `json:"is_free_shipping,omitempty"`
+	IsVisible               *bool            `json:"is_visible,omitempty"`
+	IsFeatured              bool             `json:"is_featured,omitempty"`
+	RelatedProducts         []int64          `json:"related_products,omitempty"`
+	Warranty                string           `json:"warranty,omitempty"`
+	BinPickingNumber        string           `json:"bin_picking_number,omitempty"`
+	Upc                     string           `json:"upc,omitempty"`
+	Mpn                     string           `json:"mpn,omitempty"`
+	Gtin                    string           `json:"gtin,omitempty"`
+	SearchKeywords          string           `json:"search_keywords,omitempty"`
+	Availability            string           `json:"availability,omitempty"`
+	AvailabilityDescription string           `json:"availability_description,omitempty"`
+	SortOrder               int64            `json:"sort_order,omitempty"`
+	Condition               string           `json:"condition,omitempty"`
+	IsConditionShown        bool             `json:"is_condition_shown,omitempty"`
+	OrderQuantityMinimum    int64            `json:"order_quantity_minimum,omitempty"`
+	OrderQuantityMaximum    int64            `json:"order_quantity_maximum,omitempty"`
+	PageTitle               string           `json:"page_title,omitempty"`
+	MetaKeywords            []string         `json:"meta_keywords,omitempty"`
+	MetaDescription         string           `json:"meta_description,omitempty"`
+	CustomURL               *CustomURL       `json:"custom_url,omitempty"`
+	CustomFields            []CustomField    `json:"custom_fields,omitempty"`
+}
+
+// ProductUpdate is the body of BigCommerce PUT /catalog/products/{id}, only the fields that are set are sent so it
+// can be used as a patch. ID is only needed for UpdateProducts, where it says which product each patch is for
+type ProductUpdate struct {
+	ID                      int64            `json:"id,omitempty"`
+	Name                    *string          `json:"name,omitempty"`
+	Type                    *string          `json:"type,omitempty"`
+	Sku                     *string          `json:"sku,omitempty"`
+	Description             *string          `json:"description,omitempty"`
+	Weight                  *float64         `json:"weight,omitempty"`
+	Width                   *float64         `json:"width,omitempty"`
+	Depth                   *float64         `json:"depth,omitempty"`
+	Height                  *float64         `json:"height,omitempty"`
+	Price                   *primative.Money `json:"price,omitempty"`
+	CostPrice               *primative.Money `json:"cost_price,omitempty"`
+	RetailPrice             *primative.Money `json:"retail_price,omitempty"`
+	SalePrice               *primative.Money `json:"sale_price,omitempty"`
+	MapPrice                *primative.Money `json:"map_price,omitempty"`
+	TaxClassID              *int64           `json:"tax_class_id,omitempty"`
+	ProductTaxCode          *string          `json:"product_tax_code,omitempty"`
+	Categories              []int64          `json:"categories,omitempty"`
+	BrandID                 *int64           `json:"brand_id,omitempty"`
+	InventoryLevel          *int64           `json:"inventory_level,omitempty"`
+	InventoryWarningLevel   *int64           `json:"inventory_warning_level,omitempty"`
+	InventoryTracking       *string          `json:"inventory_tracking,omitempty"`
+	FixedCostShippingPrice  *primative.Money `json:"fixed_cost_shipping_price,omitempty"`
+	IsFreeShipping          *bool            `json:"is_free_shipping,omitempty"`
+	IsVisible               *bool            `json:"is_visible,omitempty"`
+	IsFeatured              *bool            `json:"is_featured,omitempty"`
+	RelatedProducts         []int64          `json:"related_products,omitempty"`
+	Warranty                *string          `json:"warranty,omitempty"`
+	BinPickingNumber        *string          `json:"bin_picking_number,omitempty"`
+	Upc                     *string          `json:"upc,omitempty"`
+	Mpn                     *string          `json:"mpn,omitempty"`
+	Gtin                    *string          `json:"gtin,omitempty"`
+	SearchKeywords          *string          `json:"search_keywords,omitempty"`
+	Availability            *string          `json:"availability,omitempty"`
+	AvailabilityDescription *string          `json:"availability_description,omitempty"`
+	SortOrder               *int64           `json:"sort_order,omitempty"`
+	Condition               *string          `json:"condition,omitempty"`
+	IsConditionShown        *bool            `json:"is_condition_shown,omitempty"`
+	OrderQuantityMinimum    *int64           `json:"order_quantity_minimum,omitempty"`
+	OrderQuantityMaximum    *int64           `json:"order_quantity_maximum,omitempty"`
+	PageTitle               *string          `json:"page_title,omitempty"`
+	MetaKeywords            []string         `json:"meta_keywords,omitempty"`
+	MetaDescription         *string          `json:"meta_description,omitempty"`
+	CustomURL               *CustomURL       `json:"custom_url,omitempty"`
+}
+
+// Validate checks the fields BigCommerce requires to create a product are filled in
+func (p ProductCreate) Validate() error {
+	if p.Name == "" {
+		return errors.New("catalog: product name is required")
+	}
+	if err := validateType(p.Type); err != nil {
+		return fmt.Errorf("catalog: %w", err)
+	}
+	if p.Weight < 0 {
+		return errors.New("catalog: product weight can not be negative")
+	}
+	if p.Price.Sign() < 0 {
+		return errors.New("catalog: product price can not be negative")
+	}
+	return nil
+}
+
+// Validate checks the parts of the patch that are set make sense
+func (p ProductUpdate) Validate() error {
+	if err := p.validate(); err != nil {
+		return fmt.Errorf("catalog: %w", err)
+	}
+	return nil
+}
+
+func (p ProductUpdate) validate() error {
+	if p.Name != nil && *p.Name == "" {
+		return errors.New("product name can not be emptied")
+	}
+	if p.Type != nil {
+		if err := validateType(*p.Type); err != nil {
+			return err
+		}
+	}
+	if p.Weight != nil && *p.Weight < 0 {
+		return errors.New("product weight can not be negative")
+	}
+	if p.Price != nil && p.Price.Sign() < 0 {
+		return errors.New("product price can not be negative")
+	}
+	return nil
+}
+
+func validateType(t string) error {
+	if t != ProductTypePhysical && t != ProductTypeDigital {
+		return fmt.Errorf("product type must be %s or %s, not %q", ProductTypePhysical, ProductTypeDigital, t)
+	}
+	return nil
+}
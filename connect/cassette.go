@@ -0,0 +1,316 @@
+package connect
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// CassetteMode picks whether a Cassette talks to BigCommerce or plays back what it recorded
+type CassetteMode int
+
+const (
+	// ModeReplay answers every request from the cassette file and never touches the network
+	ModeReplay CassetteMode = iota
+	// ModeRecord sends every request on and saves each request/response pair to the cassette file
+	ModeRecord
+)
+
+// redacted replaces auth headers and customer details in recorded interactions
+const redacted = "REDACTED"
+
+// scrubbedHeaders are request headers never written to a cassette
+var scrubbedHeaders = []string{"X-Auth-Token", "X-Auth-Client", "Authorization", "Cookie", "Set-Cookie"}
+
+// piiFields are json keys (and query params) whose values are customer details, they are redacted before recording
+var piiFields = map[string]bool{
+	"first_name":       true,
+	"last_name":        true,
+	"company":          true,
+	"street_1":         true,
+	"street_2":         true,
+	"city":             true,
+	"zip":              true,
+	"phone":            true,
+	"email":            true,
+	"ip_address":       true,
+	"ip_address_v6":    true,
+	"customer_message": true,
+}
+
+// Interaction is a single recorded request and the response it got
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the scrubbed request half of an Interaction
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the scrubbed response half of an Interaction
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette is an http.RoundTripper that records BigCommerce traffic to a json file and plays it back, so code like
+// GetHydratedOrders can run deterministically without a store. Auth headers and customer details are scrubbed from
+// everything written to the file.
+//
+// Recorded requests are matched on method, path and query. When the same request was recorded more than once the
+// responses are played back in the order they were recorded, the last one being repeated after that.
+//
+//	cassette, err := connect.NewCassette("testdata/orders.json", connect.ModeReplay)
+//	client.Transport = cassette
+type Cassette struct {
+	Path string
+	Mode CassetteMode
+	// Transport sends the requests while recording, the pooled transport BCClient uses by default when nil
+	Transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	played       map[int]bool
+}
+
+// NewCassette opens the cassette at path. In ModeReplay the file has to exist, in ModeRecord it is created (or
+// overwritten) by the first recorded request
+func NewCassette(path string, mode CassetteMode) (*Cassette, error) {
+	c := &Cassette{Path: path, Mode: mode, played: map[int]bool{}}
+	if mode == ModeRecord {
+		return c, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &c.interactions)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Interactions returns a copy of everything recorded or loaded so far
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// RoundTrip records or replays req depending on the cassette's Mode
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	if c.Mode == ModeRecord {
+		return c.record(req)
+	}
+	return c.replay(req)
+}
+
+func (c *Cassette) record(req *http.Request) (*http.Response, error) {
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	// a RoundTripper must not modify the caller's request, the body read for the cassette is sent on a clone
+	out := req.Clone(req.Context())
+	if reqBody != nil {
+		out.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+	transport := c.Transport
+	if transport == nil {
+		transport = defaultTransport
+	}
+	resp, err := transport.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	// the caller still gets the real, unscrubbed response
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			URL:    scrubURL(req.URL),
+			Header: scrubHeader(req.Header),
+			Body:   string(scrubBody(reqBody)),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       string(scrubBody(respBody)),
+		},
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.interactions = append(c.interactions, interaction)
+	return resp, c.save()
+}
+
+// readRequestBody returns the body of req, from a fresh copy through GetBody when the request has one so that
+// req.Body is left for the caller to retry with. Otherwise req.Body is read and closed, as sending req would
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body := req.Body
+	if req.GetBody != nil {
+		var err error
+		body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer body.Close()
+	return ioutil.ReadAll(body)
+}
+
+func (c *Cassette) replay(req *http.Request) (*http.Response, error) {
+	key, err := matchKey(req.Method, scrubURL(req.URL))
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := -1
+	for i, in := range c.interactions {
+		recordedKey, err := matchKey(in.Request.Method, in.Request.URL)
+		if err != nil || recordedKey != key {
+			continue
+		}
+		found = i
+		if !c.played[i] {
+			break
+		}
+	}
+	if found < 0 {
+		return nil, fmt.Errorf("cassette: no recorded response for %s %s", req.Method, req.URL)
+	}
+	c.played[found] = true
+
+	recorded := c.interactions[found].Response
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// save writes the cassette file, the caller must hold mu
+func (c *Cassette) save() error {
+	if c.Path == "" {
+		return errors.New("cassette: no path to record to")
+	}
+	var data bytes.Buffer
+	encoder := json.NewEncoder(&data)
+	// keep the & of query strings readable
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(c.interactions)
+	if err != nil {
+		return err
+	}
+	tmp := c.Path + ".tmp"
+	err = ioutil.WriteFile(tmp, data.Bytes(), 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.Path)
+}
+
+// matchKey is what requests are matched on, the method, path and query with the params sorted
+func matchKey(method string, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return method + " " + u.Path + "?" + u.Query().Encode(), nil
+}
+
+func scrubURL(u *url.URL) string {
+	scrubbed := *u
+	q := scrubbed.Query()
+	for key := range q {
+		if piiFields[key] {
+			q.Set(key, redacted)
+		}
+	}
+	scrubbed.RawQuery = q.Encode()
+	scrubbed.User = nil
+	return scrubbed.String()
+}
+
+func scrubHeader(h http.Header) http.Header {
+	scrubbed := h.Clone()
+	for _, name := range scrubbedHeaders {
+		scrubbed.Del(name)
+	}
+	return scrubbed
+}
+
+// scrubBody redacts customer details from a json body, anything that is not json is kept as it is
+func scrubBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	// keep numbers exactly as they were sent
+	decoder.UseNumber()
+	if decoder.Decode(&v) != nil {
+		return body
+	}
+	scrubbed, err := json.Marshal(scrubValue(v))
+	if err != nil {
+		return body
+	}
+	return scrubbed
+}
+
+func scrubValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if _, isString := value.(string); isString && piiFields[key] {
+				if value != "" {
+					t[key] = redacted
+				}
+				continue
+			}
+			t[key] = scrubValue(value)
+		}
+	case []interface{}:
+		for i := range t {
+			t[i] = scrubValue(t[i])
+		}
+	}
+	return v
+}
@@ -0,0 +1,170 @@
+package connect
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+type cassetteOrder struct {
+	ID        int64  `json:"id"`
+	Status    string `json:"status"`
+	FirstName string `json:"first_name"`
+	Email     string `json:"email"`
+}
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if r.Header.Get("X-Auth-Token") != "secret-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		status := "Pending"
+		if n > 1 {
+			status = "Shipped"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":101,"status":"` + status + `","first_name":"Ada","email":"ada@example.com"}`))
+	}))
+	path := filepath.Join(t.TempDir(), "orders.json")
+
+	recorder, err := NewCassette(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recording := NewClient("secret-token", "client-id", "store")
+	recording.SetBaseURL(srv.URL + "/")
+	recording.Limiter = nil
+	recording.Transport = recorder
+	for _, want := range []string{"Pending", "Shipped"} {
+		var o cassetteOrder
+		err = recording.GetAndUnmarshal("v2/orders/101", &o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the caller sees the real response, only the file is scrubbed
+		if o.Status != want || o.FirstName != "Ada" {
+			t.Fatalf("recorded %+v, want status %s with the customer's name", o, want)
+		}
+	}
+	srv.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-token", "client-id", "Ada", "ada@example.com"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette file contains %q:\n%s", secret, data)
+		}
+	}
+
+	player, err := NewCassette(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the server is gone, every answer has to come from the file
+	replaying := NewClient("other-token", "other-client", "store")
+	replaying.SetBaseURL(srv.URL + "/")
+	replaying.Limiter = nil
+	replaying.Transport = player
+	for _, want := range []string{"Pending", "Shipped", "Shipped"} {
+		var o cassetteOrder
+		err = replaying.GetAndUnmarshal("v2/orders/101", &o)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if o.ID != 101 || o.Status != want || o.FirstName != redacted {
+			t.Errorf("replayed %+v, want status %s with a redacted name", o, want)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("server saw %d requests, want only the 2 recorded ones", calls)
+	}
+}
+
+func TestCassetteReplayUnmatched(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "orders.json")
+	err := ioutil.WriteFile(path, []byte(`[{"request":{"method":"GET","url":"https://api.example.com/v2/orders?status_id=11"},"response":{"status_code":200,"body":"[]"}}]`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	player, err := NewCassette(path, ModeReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		method, url string
+		match       bool
+	}{
+		{"GET", "https://elsewhere.example.com/v2/orders?status_id=11", true},
+		{"GET", "https://api.example.com/v2/orders?status_id=10", false},
+		{"GET", "https://api.example.com/v2/products?status_id=11", false},
+		{"DELETE", "https://api.example.com/v2/orders?status_id=11", false},
+	} {
+		req, err := http.NewRequest(tt.method, tt.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := player.RoundTrip(req)
+		if tt.match {
+			if err != nil || resp.StatusCode != http.StatusOK {
+				t.Errorf("%s %s should have been replayed, got %v", tt.method, tt.url, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+			t.Errorf("%s %s = %v, want an error for a request that was never recorded", tt.method, tt.url, err)
+		}
+	}
+
+	if _, err := NewCassette(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("replaying a cassette that does not exist should fail")
+	}
+}
+
+func TestCassetteRecordLeavesRequestAlone(t *testing.T) {
+	var sent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		sent = string(body)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+	cassette, err := NewCassette(filepath.Join(t.TempDir(), "write.json"), ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL+"/v2/orders/101", strings.NewReader(`{"status_id":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := req.Body
+	resp, err := cassette.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if sent != `{"status_id":2}` {
+		t.Errorf("server got %q, want the request body", sent)
+	}
+	if got := cassette.Interactions()[0].Request.Body; got != `{"status_id":2}` {
+		t.Errorf("recorded body %q, want the request body", got)
+	}
+	if req.Body != body {
+		t.Error("recording replaced the caller's request body")
+	}
+	// the body came from GetBody, so the caller's is still unread and the request can be sent again
+	left, _ := ioutil.ReadAll(req.Body)
+	if string(left) != `{"status_id":2}` {
+		t.Errorf("caller's body has %q left, want it unread", left)
+	}
+}
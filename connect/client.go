@@ -1,10 +1,14 @@
 package connect
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"time"
 )
 
 const baseBCURL string = "https://api.bigcommerce.com/stores/"
@@ -16,51 +20,131 @@ type BCClient struct {
 	StoreKey   string
 	BaseURL    string
 	Limit      int
+	Retry      RetryPolicy
+	// Limiter is waited on before every request, NewClient shares one limiter between all clients of a store, see StoreLimiter
+	Limiter *RateLimiter
+	// Concurrency is how many requests the batch helpers keep in flight at once, DefaultConcurrency when not set
+	Concurrency int
+	// HTTPClient sends the requests, when nil a shared client that pools connections to BigCommerce is used
+	HTTPClient *http.Client
+	// Transport replaces the transport of HTTPClient when set, e.g. a Cassette to record or replay traffic
+	Transport http.RoundTripper
+	// Middleware wraps the transport, see Use
+	Middleware []Middleware
+	// Logger gets a record of every request when set, see Logger
+	Logger Logger
+	// Telemetry gets a span and metrics for every request, the global OpenTelemetry providers are used when nil
+	Telemetry *Telemetry
 }
 
 //NewClient create a new client wrapper based on BC connection details, default result limit is set to 50
+// and throttled or unavailable requests are retried with DefaultRetryPolicy. Every client for the same store, base url
+// and auth client shares one RateLimiter, see StoreLimiter
 func NewClient(authToken, authClient, storeKey string) *BCClient {
 	return &BCClient{
-		AuthToken:  authToken,
-		AuthClient: authClient,
-		StoreKey:   storeKey,
-		BaseURL:    baseBCURL,
-		Limit:      50,
+		AuthToken:   authToken,
+		AuthClient:  authClient,
+		StoreKey:    storeKey,
+		BaseURL:     baseBCURL,
+		Limit:       50,
+		Retry:       DefaultRetryPolicy(),
+		Limiter:     StoreLimiter(baseBCURL, storeKey, authClient),
+		Concurrency: DefaultConcurrency,
 	}
 }
 
-// SetBaseURL will override the default (https://api.bigcommerce.com/stores/) base url of the client to the string passed in
+// SetBaseURL will override the default (https://api.bigcommerce.com/stores/) base url of the client to the string passed in,
+// a client still on the shared limiter of its old base url moves to the one of the new base url
 func (s *BCClient) SetBaseURL(url string) {
+	if s.Limiter != nil && s.Limiter == StoreLimiter(s.BaseURL, s.StoreKey, s.AuthClient) {
+		s.Limiter = StoreLimiter(url, s.StoreKey, s.AuthClient)
+	}
 	s.BaseURL = url
 }
 
 // DoRequest will call out to bigcommerce API for the passed in request, this is mostly used internal to the
 // package but can be used to expand on the library
+//
+// The request is bound to whatever context it was built with, see BuildUrlRequestContext. Throttled and
+// temporarily unavailable responses are retried according to the client's Retry policy, and every attempt waits on
+// the client's Limiter first. Any response of 300 or above comes back as an *APIError. Each request is traced as a
+// span of its own and counted in the client's metrics, see Telemetry
 func (s *BCClient) DoRequest(req *http.Request) ([]byte, error) {
 	req.Header.Add("accept", "application/json")
 	req.Header.Add("content-type", "application/json")
 	req.Header.Add("x-auth-token", s.AuthToken)
 	req.Header.Add("x-auth-client", s.AuthClient)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	req, span := s.startRequest(req)
+	start := time.Now()
+	resp, body, retries, err := s.sendWithRetries(req)
+	duration := time.Since(start)
+	s.logRequest(req, resp, len(body), retries, duration, err)
+	s.endRequest(req, span, resp, retries, duration, err)
 	if err != nil {
 		return nil, err
 	}
+	return body, nil
+}
+
+// sendWithRetries sends req until it succeeds or the Retry policy gives up, it returns the last response along with
+// how many times the request was retried
+func (s *BCClient) sendWithRetries(req *http.Request) (resp *http.Response, body []byte, retries int, err error) {
+	client := s.httpClient()
+	for attempt := 0; ; attempt++ {
+		err = s.Limiter.Wait(req.Context())
+		if err != nil {
+			return resp, nil, attempt, err
+		}
+		attemptReq, span := s.startAttempt(req, attempt)
+		resp, body, err = send(client, attemptReq)
+		endAttempt(span, resp, err)
+		if err != nil {
+			return nil, nil, attempt, err
+		}
+		s.logAttempt(req, resp, body, attempt)
+		s.Limiter.observe(resp)
+		if resp.StatusCode < 300 {
+			return resp, body, attempt, nil
+		}
+		if !s.Retry.shouldRetry(req, resp.StatusCode, attempt) {
+			return resp, body, attempt, newAPIError(req, resp, body)
+		}
+		delay := s.Retry.delay(resp, attempt)
+		spanRetry(req, resp.StatusCode, attempt, delay)
+		err = sleepContext(req.Context(), delay)
+		if err != nil {
+			return resp, nil, attempt, err
+		}
+		req, err = rewindRequest(req)
+		if err != nil {
+			return resp, nil, attempt, err
+		}
+	}
+}
+
+// send does a single round trip and reads the whole response body
+func send(client *http.Client, req *http.Request) (*http.Response, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
 	defer resp.Body.Close()
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	if resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("%s", body)
-	}
-	return body, nil
+	return resp, body, nil
 }
 
 // GetBody - gets the request body of the url
 func (s *BCClient) GetBody(url string) (body []byte, err error) {
-	req, err := http.NewRequest("GET", url, nil)
+	return s.GetBodyContext(context.Background(), url)
+}
+
+// GetBodyContext - gets the request body of the url, the request is cancelled when ctx is done
+func (s *BCClient) GetBodyContext(ctx context.Context, url string) (body []byte, err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return
 	}
@@ -70,9 +154,29 @@ func (s *BCClient) GetBody(url string) (body []byte, err error) {
 
 // BuildUrl - gets the golang request out of the endpoint (e.g. /v2/orders/) needed for sending to the BC api
 func (s *BCClient) BuildUrlRequest(endpoint string) (req *http.Request, err error) {
+	return s.BuildUrlRequestContext(context.Background(), endpoint)
+}
+
+// BuildUrlRequestContext - same as BuildUrlRequest but the returned request is bound to ctx
+func (s *BCClient) BuildUrlRequestContext(ctx context.Context, endpoint string) (req *http.Request, err error) {
+	return s.BuildRequestContext(ctx, "GET", endpoint, nil)
+}
+
+// BuildRequestContext - gets the golang request for any method out of the endpoint (e.g. /v2/orders/), inData is
+// marshalled to json as the request body unless it is nil
+func (s *BCClient) BuildRequestContext(ctx context.Context, method string, endpoint string, inData interface{}) (req *http.Request, err error) {
 	url := fmt.Sprintf(s.BaseURL+"%s/%s", s.StoreKey, endpoint)
 
-	req, err = http.NewRequest("GET", url, nil)
+	var body io.Reader
+	if inData != nil {
+		b, err := json.Marshal(inData)
+		if err != nil {
+			return nil, err
+		}
+		// a bytes.Reader lets the request be replayed when it is retried
+		body = bytes.NewReader(b)
+	}
+	req, err = http.NewRequestWithContext(ctx, method, url, body)
 	return
 }
 
@@ -80,7 +184,12 @@ func (s *BCClient) BuildUrlRequest(endpoint string) (req *http.Request, err erro
 //
 // Example of the endpoint parameter would be "/v2/orders/" and the client will handle the store key and base url pieces
 func (s *BCClient) GetAndUnmarshal(endpoint string, outData interface{}) error {
-	req, err := s.BuildUrlRequest(endpoint)
+	return s.GetAndUnmarshalContext(context.Background(), endpoint, outData)
+}
+
+// GetAndUnmarshalContext - same as GetAndUnmarshal but the request is cancelled when ctx is done
+func (s *BCClient) GetAndUnmarshalContext(ctx context.Context, endpoint string, outData interface{}) error {
+	req, err := s.BuildUrlRequestContext(ctx, endpoint)
 	if err != nil {
 		return err
 	}
@@ -94,7 +203,7 @@ func (s *BCClient) doUnmarshalling(req *http.Request, outData interface{}) error
 		return err
 	}
 
-	if len(res) > 0 {
+	if len(res) > 0 && outData != nil {
 		err = json.Unmarshal(res, outData)
 		if err != nil {
 			return err
@@ -109,7 +218,12 @@ func (s *BCClient) doUnmarshalling(req *http.Request, outData interface{}) error
 // the client will not manipulate the endpoint in any way.
 // This function is used by things like Resource.EagerGet
 func (s *BCClient) GetAndUnmarshalRaw(fullEndpoint string, outData interface{}) error {
-	req, err := http.NewRequest("GET", fullEndpoint, nil)
+	return s.GetAndUnmarshalRawContext(context.Background(), fullEndpoint, outData)
+}
+
+// GetAndUnmarshalRawContext - same as GetAndUnmarshalRaw but the request is cancelled when ctx is done
+func (s *BCClient) GetAndUnmarshalRawContext(ctx context.Context, fullEndpoint string, outData interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", fullEndpoint, nil)
 	if err != nil {
 		return err
 	}
@@ -120,7 +234,12 @@ func (s *BCClient) GetAndUnmarshalRaw(fullEndpoint string, outData interface{})
 //
 // Example of the endpoint parameter would be "/v2/orders/count/" and the client will handle the store key and base url pieces
 func (s *BCClient) GetAndUnmarshalWithQuery(endpoint string, rawQuery string, outData interface{}) error {
-	req, err := s.BuildUrlRequest(endpoint)
+	return s.GetAndUnmarshalWithQueryContext(context.Background(), endpoint, rawQuery, outData)
+}
+
+// GetAndUnmarshalWithQueryContext - same as GetAndUnmarshalWithQuery but the request is cancelled when ctx is done
+func (s *BCClient) GetAndUnmarshalWithQueryContext(ctx context.Context, endpoint string, rawQuery string, outData interface{}) error {
+	req, err := s.BuildUrlRequestContext(ctx, endpoint)
 	if err != nil {
 		return err
 	}
@@ -129,6 +248,60 @@ func (s *BCClient) GetAndUnmarshalWithQuery(endpoint string, rawQuery string, ou
 	return s.doUnmarshalling(req, outData)
 }
 
+// PostAndUnmarshal - posts inData as json to the endpoint and unmarshals the response body to passed in struct pointer
+//
+// Example of the endpoint parameter would be "v2/orders" and the client will handle the store key and base url pieces,
+// outData can be nil if the response body is not needed
+func (s *BCClient) PostAndUnmarshal(endpoint string, inData interface{}, outData interface{}) error {
+	return s.PostAndUnmarshalContext(context.Background(), endpoint, inData, outData)
+}
+
+// PostAndUnmarshalContext - same as PostAndUnmarshal but the request is cancelled when ctx is done
+func (s *BCClient) PostAndUnmarshalContext(ctx context.Context, endpoint string, inData interface{}, outData interface{}) error {
+	req, err := s.BuildRequestContext(ctx, "POST", endpoint, inData)
+	if err != nil {
+		return err
+	}
+	return s.doUnmarshalling(req, outData)
+}
+
+// PutAndUnmarshal - puts inData as json to the endpoint and unmarshals the response body to passed in struct pointer
+//
+// Example of the endpoint parameter would be "v2/orders/12039" and the client will handle the store key and base url
+// pieces, outData can be nil if the response body is not needed
+func (s *BCClient) PutAndUnmarshal(endpoint string, inData interface{}, outData interface{}) error {
+	return s.PutAndUnmarshalContext(context.Background(), endpoint, inData, outData)
+}
+
+// PutAndUnmarshalContext - same as PutAndUnmarshal but the request is cancelled when ctx is done
+func (s *BCClient) PutAndUnmarshalContext(ctx context.Context, endpoint string, inData interface{}, outData interface{}) error {
+	req, err := s.BuildRequestContext(ctx, "PUT", endpoint, inData)
+	if err != nil {
+		return err
+	}
+	return s.doUnmarshalling(req, outData)
+}
+
+// Delete - sends a delete request to the endpoint, BigCommerce responds with an empty body so nothing is unmarshalled
+//
+// Example of the endpoint parameter would be "v2/orders/12039/shipments/3" and the client will handle the store key
+// and base url pieces
+func (s *BCClient) Delete(endpoint string) error {
+	return s.DeleteContext(context.Background(), endpoint)
+}
+
+// DeleteContext - same as Delete but the request is cancelled when ctx is done
+func (s *BCClient) DeleteContext(ctx context.Context, endpoint string) error {
+	req, err := s.BuildRequestContext(ctx, "DELETE", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	_, err = s.DoRequest(req)
+	return err
+}
+
+// Client is the set of BCClient methods the library was first released with. It is kept as it is so implementations
+// and mocks written against it keep compiling, the methods added since are on APIClient
 type Client interface {
 	SetBaseURL(url string)
 	DoRequest(req *http.Request) ([]byte, error)
@@ -138,3 +311,29 @@ type Client interface {
 	GetAndUnmarshalRaw(fullEndpoint string, outData interface{}) error
 	GetAndUnmarshalWithQuery(endpoint string, rawQuery string, outData interface{}) error
 }
+
+// APIClient is everything BCClient does, the Client methods along with the context aware, writing and V3 ones
+type APIClient interface {
+	Client
+	GetBodyContext(ctx context.Context, url string) (body []byte, err error)
+	BuildUrlRequestContext(ctx context.Context, endpoint string) (req *http.Request, err error)
+	BuildRequestContext(ctx context.Context, method string, endpoint string, inData interface{}) (req *http.Request, err error)
+	GetAndUnmarshalContext(ctx context.Context, endpoint string, outData interface{}) error
+	GetAndUnmarshalRawContext(ctx context.Context, fullEndpoint string, outData interface{}) error
+	GetAndUnmarshalWithQueryContext(ctx context.Context, endpoint string, rawQuery string, outData interface{}) error
+	PostAndUnmarshal(endpoint string, inData interface{}, outData interface{}) error
+	PostAndUnmarshalContext(ctx context.Context, endpoint string, inData interface{}, outData interface{}) error
+	PutAndUnmarshal(endpoint string, inData interface{}, outData interface{}) error
+	PutAndUnmarshalContext(ctx context.Context, endpoint string, inData interface{}, outData interface{}) error
+	Delete(endpoint string) error
+	DeleteContext(ctx context.Context, endpoint string) error
+	DoV3Context(ctx context.Context, method string, endpoint string, rawQuery string, inData interface{}, outData interface{}) (*Meta, error)
+	GetV3AndUnmarshal(endpoint string, rawQuery string, outData interface{}) (*Meta, error)
+	GetV3AndUnmarshalContext(ctx context.Context, endpoint string, rawQuery string, outData interface{}) (*Meta, error)
+	WalkPages(endpoint string, rawQuery string, fn func(data json.RawMessage, p Pagination) error) error
+	WalkPagesContext(ctx context.Context, endpoint string, rawQuery string, fn func(data json.RawMessage, p Pagination) error) error
+	GetAllPages(endpoint string, rawQuery string, outSlice interface{}) error
+	GetAllPagesContext(ctx context.Context, endpoint string, rawQuery string, outSlice interface{}) error
+}
+
+var _ APIClient = (*BCClient)(nil)
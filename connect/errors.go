@@ -0,0 +1,145 @@
+package connect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned by DoRequest, and everything built on it, when BigCommerce responds with a status of 300 or
+// above. It understands both the V2 error array and the V3 problem body, the raw body is always kept in Body
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// RequestID is the X-Request-ID BigCommerce tags every response with, quote it when raising a support ticket
+	RequestID string
+	Header    http.Header
+	Body      []byte
+
+	// Title, Type, Detail and Errors are filled from a V3 problem body
+	Title  string
+	Type   string
+	Detail string
+	Errors map[string]string
+
+	// Messages is filled from a V2 error array
+	Messages []ErrorMessage
+}
+
+// ErrorMessage is a single entry of the error array returned by V2 endpoints
+type ErrorMessage struct {
+	Status  int                    `json:"status"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// v3Problem is the error body returned by V3 endpoints
+type v3Problem struct {
+	Status int             `json:"status"`
+	Title  string          `json:"title"`
+	Type   string          `json:"type"`
+	Detail string          `json:"detail"`
+	Errors json.RawMessage `json:"errors"`
+}
+
+// newAPIError builds the error for a failed response, body is the already read response body
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		RequestID:  resp.Header.Get("X-Request-ID"),
+		Header:     resp.Header,
+		Body:       body,
+	}
+
+	trimmed := strings.TrimSpace(string(body))
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		var messages []ErrorMessage
+		if json.Unmarshal(body, &messages) == nil {
+			apiErr.Messages = messages
+		}
+	case strings.HasPrefix(trimmed, "{"):
+		var problem v3Problem
+		if json.Unmarshal(body, &problem) == nil {
+			apiErr.Title = problem.Title
+			apiErr.Type = problem.Type
+			apiErr.Detail = problem.Detail
+			// errors is usually a field to message map, but some endpoints send an empty array instead
+			var fieldErrors map[string]string
+			if json.Unmarshal(problem.Errors, &fieldErrors) == nil && len(fieldErrors) > 0 {
+				apiErr.Errors = fieldErrors
+			}
+		}
+	}
+	return apiErr
+}
+
+// Error gives the method, url and status of the failed request along with the best message we could find in the body
+func (e *APIError) Error() string {
+	return fmt.Sprintf("bigcommerce: %s %s: %d %s: %s",
+		e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode), e.Message())
+}
+
+// Message returns the human readable part of the error body, falling back to the raw body
+func (e *APIError) Message() string {
+	if len(e.Messages) > 0 {
+		parts := make([]string, 0, len(e.Messages))
+		for _, m := range e.Messages {
+			parts = append(parts, m.Message)
+		}
+		return strings.Join(parts, "; ")
+	}
+	if e.Title != "" {
+		msg := e.Title
+		if e.Detail != "" {
+			msg += ": " + e.Detail
+		}
+		fields := make([]string, 0, len(e.Errors))
+		for field := range e.Errors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			msg += fmt.Sprintf("; %s: %s", field, e.Errors[field])
+		}
+		return msg
+	}
+	return strings.TrimSpace(string(e.Body))
+}
+
+// HasStatus reports whether err is, or wraps, an APIError with the given status code
+func HasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// IsNotFound reports whether err is, or wraps, a 404 from BigCommerce
+func IsNotFound(err error) bool {
+	return HasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is, or wraps, a 401 from BigCommerce, usually a bad or revoked token
+func IsUnauthorized(err error) bool {
+	return HasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err is, or wraps, a 403 from BigCommerce, usually a token missing an OAuth scope
+func IsForbidden(err error) bool {
+	return HasStatus(err, http.StatusForbidden)
+}
+
+// IsRateLimited reports whether err is, or wraps, a 429 from BigCommerce that ran out of retries
+func IsRateLimited(err error) bool {
+	return HasStatus(err, http.StatusTooManyRequests)
+}
+
+// IsConflict reports whether err is, or wraps, a 409 from BigCommerce
+func IsConflict(err error) bool {
+	return HasStatus(err, http.StatusConflict)
+}
@@ -0,0 +1,166 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultConcurrency is how many requests a fan-out keeps in flight when BCClient.Concurrency is not set
+const DefaultConcurrency = 20
+
+// Default quota used until the store tells us its own, this is the BigCommerce standard plan limit
+const (
+	defaultQuotaRequests = 150
+	defaultQuotaWindow   = 30 * time.Second
+)
+
+// RateLimiter is a token bucket that every request made by a BCClient waits on before it is sent.
+//
+// The bucket holds up to a full window worth of requests and refills evenly over the window. It tunes itself from the
+// X-Rate-Limit-Requests-Quota, X-Rate-Limit-Time-Window-Ms and X-Rate-Limit-Requests-Left headers BigCommerce
+// returns, so the configured rate only matters until the first response comes back. A nil RateLimiter never waits
+type RateLimiter struct {
+	mu       sync.Mutex
+	requests int
+	window   time.Duration
+	tokens   float64
+	last     time.Time
+}
+
+// NewRateLimiter creates a limiter allowing requests per window, the bucket starts full
+func NewRateLimiter(requests int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		requests: requests,
+		window:   window,
+		tokens:   float64(requests),
+		last:     time.Now(),
+	}
+}
+
+// limiterKey is what makes two clients spend the same quota, the same store reached through the same API account on
+// the same host
+type limiterKey struct {
+	baseURL    string
+	storeKey   string
+	authClient string
+}
+
+var (
+	storeLimitersMu sync.Mutex
+	storeLimiters   = map[limiterKey]*RateLimiter{}
+)
+
+// StoreLimiter returns the limiter shared by every client of storeKey at baseURL that authenticates as authClient,
+// creating it with the default quota the first time it is asked for. Sandbox and production stores, or two API
+// accounts of the same store, get a limiter each
+func StoreLimiter(baseURL, storeKey, authClient string) *RateLimiter {
+	storeLimitersMu.Lock()
+	defer storeLimitersMu.Unlock()
+	key := limiterKey{baseURL: baseURL, storeKey: storeKey, authClient: authClient}
+	l, ok := storeLimiters[key]
+	if !ok {
+		l = NewRateLimiter(defaultQuotaRequests, defaultQuotaWindow)
+		storeLimiters[key] = l
+	}
+	return l
+}
+
+// SetRate changes the quota of the limiter, tokens already in the bucket above the new size are dropped
+func (l *RateLimiter) SetRate(requests int, window time.Duration) {
+	if l == nil || requests <= 0 || window <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	l.requests = requests
+	l.window = window
+	if l.tokens > float64(requests) {
+		l.tokens = float64(requests)
+	}
+}
+
+// Rate returns the current quota of the limiter
+func (l *RateLimiter) Rate() (requests int, window time.Duration) {
+	if l == nil {
+		return 0, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.requests, l.window
+}
+
+// Wait blocks until a request may be sent or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+	// take the token now, even if it puts the bucket in debt, so waiters are served in the order they arrived
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.perNanosecond())
+	}
+	l.mu.Unlock()
+
+	err := sleepContext(ctx, wait)
+	if err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+	}
+	return err
+}
+
+// observe tunes the limiter from the rate limit headers of a response
+func (l *RateLimiter) observe(resp *http.Response) {
+	if l == nil {
+		return
+	}
+	quota, qErr := strconv.Atoi(resp.Header.Get("X-Rate-Limit-Requests-Quota"))
+	windowMs, wErr := strconv.Atoi(resp.Header.Get("X-Rate-Limit-Time-Window-Ms"))
+	left, lErr := strconv.Atoi(resp.Header.Get("X-Rate-Limit-Requests-Left"))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	if qErr == nil && quota > 0 {
+		l.requests = quota
+		if wErr == nil && windowMs > 0 {
+			l.window = time.Duration(windowMs) * time.Millisecond
+		}
+		if l.tokens > float64(quota) {
+			l.tokens = float64(quota)
+		}
+	}
+	if lErr == nil && left >= 0 && float64(left) < l.tokens {
+		// the store knows better than we do, other clients may be spending the same quota
+		l.tokens = float64(left)
+	}
+}
+
+// refill adds the tokens earned since the last refill, the caller must hold mu
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last)
+	l.last = now
+	if elapsed <= 0 {
+		return
+	}
+	l.tokens += float64(elapsed) * l.perNanosecond()
+	if l.tokens > float64(l.requests) {
+		l.tokens = float64(l.requests)
+	}
+}
+
+func (l *RateLimiter) perNanosecond() float64 {
+	if l.requests <= 0 || l.window <= 0 {
+		return float64(defaultQuotaRequests) / float64(defaultQuotaWindow)
+	}
+	return float64(l.requests) / float64(l.window)
+}
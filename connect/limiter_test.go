@@ -0,0 +1,179 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStoreLimiterKey(t *testing.T) {
+	prod := NewClient("token", "client", "abc123")
+	if NewClient("other-token", "client", "abc123").Limiter != prod.Limiter {
+		t.Error("clients of the same store and account should share a limiter")
+	}
+	if NewClient("token", "other-client", "abc123").Limiter == prod.Limiter {
+		t.Error("another API account of the store should get its own limiter")
+	}
+
+	sandbox := NewClient("token", "client", "abc123")
+	sandbox.SetBaseURL("https://api.sandbox.example/stores/")
+	if sandbox.Limiter == prod.Limiter {
+		t.Error("a client moved to another base url should leave the production limiter")
+	}
+	if sandbox.Limiter != StoreLimiter("https://api.sandbox.example/stores/", "abc123", "client") {
+		t.Error("a client moved to another base url should share the limiter of that base url")
+	}
+
+	own := NewRateLimiter(1, 1)
+	custom := NewClient("token", "client", "abc123")
+	custom.Limiter = own
+	custom.SetBaseURL("https://api.sandbox.example/stores/")
+	if custom.Limiter != own {
+		t.Error("SetBaseURL should keep a limiter the caller set")
+	}
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	l := NewRateLimiter(10, time.Second)
+	start := l.last
+	l.tokens = 0
+
+	l.refill(start.Add(250 * time.Millisecond))
+	if l.tokens < 2.49 || l.tokens > 2.51 {
+		t.Errorf("a quarter of the window refilled %.2f tokens, want 2.5", l.tokens)
+	}
+	l.refill(start.Add(time.Hour))
+	if l.tokens != 10 {
+		t.Errorf("the bucket holds %.2f tokens after a long idle, want it capped at 10", l.tokens)
+	}
+	l.refill(start)
+	if l.tokens != 10 || !l.last.Equal(start) {
+		t.Error("a clock going backwards should not take tokens away")
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	// 20 requests per 200ms is one every 10ms once the bucket is empty
+	l := NewRateLimiter(20, 200*time.Millisecond)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("a full bucket made 20 requests wait %s", elapsed)
+	}
+
+	start = time.Now()
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond || elapsed > time.Second {
+		t.Errorf("5 requests on an empty bucket waited %s, want about 50ms", elapsed)
+	}
+}
+
+func TestRateLimiterServesInOrder(t *testing.T) {
+	l := NewRateLimiter(1, 30*time.Millisecond)
+	l.tokens = 0
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	var order []int
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := l.Wait(ctx); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			order = append(order, i)
+			mu.Unlock()
+		}(i)
+		// each waiter takes its token, putting the bucket further in debt, before the next one arrives
+		time.Sleep(2 * time.Millisecond)
+	}
+	wg.Wait()
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("waiters were served in the order %v, want the order they arrived in", order)
+		}
+	}
+}
+
+func TestRateLimiterCancelledWaitGivesTokenBack(t *testing.T) {
+	l := NewRateLimiter(1, time.Hour)
+	l.tokens = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("a wait of an hour should have been cut short by the context")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.tokens < -0.01 {
+		t.Errorf("the bucket is left %.2f tokens in debt by a request that was never sent", l.tokens)
+	}
+}
+
+func TestRateLimiterObserve(t *testing.T) {
+	l := NewRateLimiter(defaultQuotaRequests, defaultQuotaWindow)
+	resp := &http.Response{Header: http.Header{}}
+	resp.Header.Set("X-Rate-Limit-Requests-Quota", "40")
+	resp.Header.Set("X-Rate-Limit-Time-Window-Ms", "2000")
+	resp.Header.Set("X-Rate-Limit-Requests-Left", "39")
+	l.observe(resp)
+
+	if requests, window := l.Rate(); requests != 40 || window != 2*time.Second {
+		t.Errorf("rate is %d per %s, want the store's 40 per 2s", requests, window)
+	}
+	if l.tokens > 39 {
+		t.Errorf("bucket holds %.2f tokens, want no more than the 39 the store has left", l.tokens)
+	}
+
+	// other clients spent the quota, the next request has to wait for the bucket to refill
+	resp.Header.Set("X-Rate-Limit-Requests-Left", "0")
+	l.observe(resp)
+	start := time.Now()
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("waited %s with nothing left, want about the 50ms one request takes to refill", elapsed)
+	}
+
+	// a response without the headers, or with nonsense in them, leaves the rate alone
+	l.observe(&http.Response{Header: http.Header{"X-Rate-Limit-Requests-Quota": []string{"-1"}}})
+	if requests, window := l.Rate(); requests != 40 || window != 2*time.Second {
+		t.Errorf("rate changed to %d per %s without a valid quota", requests, window)
+	}
+}
+
+func TestRateLimiterSetRate(t *testing.T) {
+	l := NewRateLimiter(100, time.Second)
+	l.SetRate(10, time.Second)
+	if requests, _ := l.Rate(); requests != 10 || l.tokens > 10 {
+		t.Errorf("after SetRate(10) the rate is %d with %.2f tokens, want both at most 10", requests, l.tokens)
+	}
+	l.SetRate(0, time.Second)
+	if requests, _ := l.Rate(); requests != 10 {
+		t.Error("a zero quota should be ignored")
+	}
+
+	var none *RateLimiter
+	if err := none.Wait(context.Background()); err != nil {
+		t.Errorf("a nil limiter should never wait, got %v", err)
+	}
+	none.SetRate(1, time.Second)
+	none.observe(&http.Response{Header: http.Header{}})
+}
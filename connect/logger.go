@@ -0,0 +1,138 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Logger receives structured records about the requests a BCClient makes, args are alternating keys and values.
+// A *slog.Logger satisfies it as it is, other structured loggers only need a thin adapter.
+//
+// Every request gets one Info record once it is done (Warn if it failed) with its method, endpoint template, status,
+// duration, response bytes, retry count and the rate limit requests left. Every attempt also gets a Debug record with
+// the headers, auth redacted, and both bodies cut to MaxLoggedBody bytes. Building the Debug record copies the
+// bodies, so a Logger that also has an Enabled method like *slog.Logger is asked first and only gets it when Debug is
+// on, other loggers always get it
+type Logger interface {
+	DebugContext(ctx context.Context, msg string, args ...interface{})
+	InfoContext(ctx context.Context, msg string, args ...interface{})
+	WarnContext(ctx context.Context, msg string, args ...interface{})
+}
+
+// levelLogger is the part of *slog.Logger that tells whether a record of a level would be written anywhere
+type levelLogger interface {
+	Enabled(ctx context.Context, level slog.Level) bool
+}
+
+// MaxLoggedBody is how many bytes of each request and response body go into a Debug record
+const MaxLoggedBody = 4 << 10
+
+// redactedHeaders are the headers whose values never make it into a log record
+var redactedHeaders = map[string]bool{
+	"X-Auth-Token":  true,
+	"X-Auth-Client": true,
+	"Authorization": true,
+	"Cookie":        true,
+	"Set-Cookie":    true,
+}
+
+func (s *BCClient) logRequest(req *http.Request, resp *http.Response, bytes int, retries int, duration time.Duration, err error) {
+	if s.Logger == nil {
+		return
+	}
+	args := []interface{}{
+		"method", req.Method,
+		"endpoint", EndpointTemplate(req.URL.Path),
+		"duration", duration,
+		"bytes", bytes,
+		"retries", retries,
+	}
+	if resp != nil {
+		args = append(args, "status", resp.StatusCode)
+		if left, convErr := strconv.Atoi(resp.Header.Get("X-Rate-Limit-Requests-Left")); convErr == nil {
+			args = append(args, "rate_limit_remaining", left)
+		}
+		if id := resp.Header.Get("X-Request-ID"); id != "" {
+			args = append(args, "request_id", id)
+		}
+	}
+	if err != nil {
+		s.Logger.WarnContext(req.Context(), "bigcommerce request failed", append(args, "error", err.Error())...)
+		return
+	}
+	s.Logger.InfoContext(req.Context(), "bigcommerce request", args...)
+}
+
+func (s *BCClient) logAttempt(req *http.Request, resp *http.Response, body []byte, attempt int) {
+	if s.Logger == nil {
+		return
+	}
+	if l, ok := s.Logger.(levelLogger); ok && !l.Enabled(req.Context(), slog.LevelDebug) {
+		return
+	}
+	var reqBody string
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			// one byte more than is logged, so a body of exactly MaxLoggedBody is not marked as cut
+			b, _ := ioutil.ReadAll(io.LimitReader(rc, MaxLoggedBody+1))
+			rc.Close()
+			reqBody = truncateBody(b, req.ContentLength)
+		}
+	}
+	s.Logger.DebugContext(req.Context(), "bigcommerce request attempt",
+		"method", req.Method,
+		"url", req.URL.String(),
+		"attempt", attempt,
+		"status", resp.StatusCode,
+		"request_header", redactHeader(req.Header),
+		"request_body", reqBody,
+		"response_header", redactHeader(resp.Header),
+		"response_body", truncateBody(body, int64(len(body))),
+	)
+}
+
+// truncateBody cuts body to MaxLoggedBody bytes, noting the full size when it is known
+func truncateBody(body []byte, size int64) string {
+	if len(body) <= MaxLoggedBody {
+		return string(body)
+	}
+	if size <= 0 {
+		return fmt.Sprintf("%s... (truncated)", body[:MaxLoggedBody])
+	}
+	return fmt.Sprintf("%s... (truncated, %d bytes)", body[:MaxLoggedBody], size)
+}
+
+func redactHeader(h http.Header) http.Header {
+	redacted := h.Clone()
+	for name := range redacted {
+		if redactedHeaders[http.CanonicalHeaderKey(name)] {
+			redacted[name] = []string{"REDACTED"}
+		}
+	}
+	return redacted
+}
+
+// EndpointTemplate turns a request path into the endpoint it belongs to by dropping everything up to the store key
+// and replacing ids with {id}, "/stores/abc/v2/orders/123/products" becomes "v2/orders/{id}/products"
+func EndpointTemplate(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for i, part := range parts {
+		if part == "v2" || part == "v3" {
+			parts = parts[i:]
+			break
+		}
+	}
+	for i, part := range parts {
+		if _, err := strconv.ParseInt(part, 10, 64); err == nil {
+			parts[i] = "{id}"
+		}
+	}
+	return strings.Join(parts, "/")
+}
@@ -0,0 +1,75 @@
+package connect
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// loggedRequest sends a PUT of a large body to a server echoing a large body back, counting how often the request
+// body was copied for logging, and returns the records logger got
+func loggedRequest(t *testing.T, level slog.Level) (records []map[string]interface{}, bodyCopies int) {
+	t.Helper()
+	large := strings.Repeat("x", 3*MaxLoggedBody)
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"echo":"` + large + `"}`))
+	})
+	var out bytes.Buffer
+	c.Logger = slog.New(slog.NewJSONHandler(&out, &slog.HandlerOptions{Level: level}))
+
+	req, err := c.BuildRequestContext(context.Background(), http.MethodPut, "v3/catalog/products", map[string]string{"name": large})
+	if err != nil {
+		t.Fatal(err)
+	}
+	getBody := req.GetBody
+	req.GetBody = func() (io.ReadCloser, error) {
+		bodyCopies++
+		return getBody()
+	}
+	_, err = c.DoRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return records, bodyCopies
+}
+
+func TestLogAttemptSkippedWithoutDebug(t *testing.T) {
+	records, copies := loggedRequest(t, slog.LevelInfo)
+	if copies != 0 {
+		t.Errorf("request body was copied %d times for a Debug record nobody wanted", copies)
+	}
+	if len(records) != 1 || records[0]["level"] != "INFO" {
+		t.Errorf("got records %v, want the single Info record", records)
+	}
+}
+
+func TestLogAttemptTruncatesBodies(t *testing.T) {
+	records, copies := loggedRequest(t, slog.LevelDebug)
+	if copies != 1 || len(records) != 2 {
+		t.Fatalf("got %d records and %d body copies, want one Debug record for the one attempt", len(records), copies)
+	}
+	debug := records[0]
+	if debug["level"] != "DEBUG" {
+		t.Fatalf("first record is %v, want the Debug record of the attempt", debug["level"])
+	}
+	for _, key := range []string{"request_body", "response_body"} {
+		body, _ := debug[key].(string)
+		if len(body) > MaxLoggedBody+64 || !strings.Contains(body, "truncated") {
+			t.Errorf("%s was logged as %d bytes, want it cut to %d", key, len(body), MaxLoggedBody)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package connect
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how BCClient retries throttled (429) and temporarily unavailable (502, 503, 504) responses.
+//
+// Only idempotent requests (GET, HEAD, OPTIONS, PUT, DELETE) are retried, a zero value policy never retries
+type RetryPolicy struct {
+	// MaxRetries is how many times a request is sent again after the first attempt
+	MaxRetries int
+	// BaseDelay is the starting delay of the jittered exponential backoff used for 5xx responses
+	BaseDelay time.Duration
+	// MaxDelay caps any single wait, including the reset window BigCommerce sends back with a 429
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is the policy NewClient starts with, 3 retries backing off from half a second up to 30 seconds
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   30 * time.Second,
+	}
+}
+
+// shouldRetry reports whether a request that came back with statusCode on its attempt'th try (0 based) is sent again
+func (p RetryPolicy) shouldRetry(req *http.Request, statusCode int, attempt int) bool {
+	if attempt >= p.MaxRetries {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// the body has been consumed and there is no way to send it again
+		return false
+	}
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+	default:
+		return false
+	}
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay works out how long to wait before the next attempt. When BigCommerce says the rate limit window is used up
+// we wait for the window to reset, otherwise we back off exponentially with full jitter
+func (p RetryPolicy) delay(resp *http.Response, attempt int) time.Duration {
+	if reset, ok := rateLimitReset(resp); ok {
+		return p.cap(reset)
+	}
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	backoff := p.BaseDelay << uint(attempt)
+	if backoff <= 0 {
+		// shifted past the end of an int64
+		backoff = p.MaxDelay
+	}
+	backoff = p.cap(backoff)
+	return time.Duration(rand.Int63n(int64(backoff)) + 1)
+}
+
+func (p RetryPolicy) cap(d time.Duration) time.Duration {
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// rateLimitReset reads the BigCommerce rate limit headers, it only returns a wait when the response was a 429 or the
+// store has no requests left in the current window
+func rateLimitReset(resp *http.Response) (time.Duration, bool) {
+	resetMs, err := strconv.Atoi(resp.Header.Get("X-Rate-Limit-Time-Reset-Ms"))
+	if err != nil || resetMs < 0 {
+		return 0, false
+	}
+	if resp.StatusCode != http.StatusTooManyRequests {
+		left, err := strconv.Atoi(resp.Header.Get("X-Rate-Limit-Requests-Left"))
+		if err != nil || left > 0 {
+			return 0, false
+		}
+	}
+	return time.Duration(resetMs) * time.Millisecond, true
+}
+
+// sleepContext waits for d or until ctx is done, whichever comes first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// rewindRequest returns a copy of req with a fresh body so it can be sent again
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		next.Body = body
+	}
+	return next, nil
+}
@@ -0,0 +1,180 @@
+package connect
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client that talks to a server answering with handler, without a rate limiter so only the
+// retry policy decides when requests are sent
+func newTestClient(t *testing.T, handler http.HandlerFunc) *BCClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := NewClient("token", "client", "store")
+	c.SetBaseURL(srv.URL + "/")
+	c.Limiter = nil
+	return c
+}
+
+// failTimes answers the first n requests with status and the rest with an empty json object, counting every request
+func failTimes(n int32, status int, header http.Header, calls *int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= n {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte("{}"))
+	}
+}
+
+func TestRetryWaitsForRateLimitReset(t *testing.T) {
+	var calls int32
+	header := http.Header{"X-Rate-Limit-Time-Reset-Ms": []string{"50"}}
+	c := newTestClient(t, failTimes(1, http.StatusTooManyRequests, header, &calls))
+	// a backoff this long would time the test out, only the reset header keeps it short
+	c.Retry = RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	start := time.Now()
+	err := c.GetAndUnmarshal("v2/orders", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > 5*time.Second {
+		t.Errorf("waited %s, want the 50ms reset window", elapsed)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	throttled := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"X-Rate-Limit-Time-Reset-Ms": []string{"1500"}},
+	}
+	if d := p.delay(throttled, 0); d != time.Second {
+		t.Errorf("throttled delay = %s, want the reset capped to MaxDelay", d)
+	}
+
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}
+	for attempt, max := range []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second} {
+		for i := 0; i < 50; i++ {
+			if d := p.delay(unavailable, attempt); d <= 0 || d > max {
+				t.Fatalf("attempt %d delay = %s, want within (0, %s]", attempt, d, max)
+			}
+		}
+	}
+}
+
+func TestRetryBacksOffOnUnavailable(t *testing.T) {
+	for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		var calls int32
+		c := newTestClient(t, failTimes(2, status, nil, &calls))
+		c.Retry = RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+		err := c.GetAndUnmarshal("v2/orders", nil)
+		if err != nil {
+			t.Fatalf("%d: %v", status, err)
+		}
+		if calls != 3 {
+			t.Errorf("%d: calls = %d, want 3", status, calls)
+		}
+	}
+}
+
+func TestRetryResendsBody(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != `{"status_id":2}` {
+			t.Errorf("attempt %d body = %q", atomic.LoadInt32(&calls)+1, body)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("{}"))
+	})
+	c.Retry = RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond}
+
+	err := c.PutAndUnmarshal("v2/orders/1", map[string]int{"status_id": 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestRetrySkipsPost(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, failTimes(1, http.StatusServiceUnavailable, nil, &calls))
+	c.Retry = RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond}
+
+	err := c.PostAndUnmarshal("v2/orders", map[string]string{}, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want a 503 APIError", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, a POST must not be sent twice", calls)
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, failTimes(10, http.StatusServiceUnavailable, nil, &calls))
+	c.Retry = RetryPolicy{MaxRetries: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := c.GetAndUnmarshalContext(ctx, "v2/orders", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("returned after %s, the backoff should have been cut short", elapsed)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, failTimes(10, http.StatusServiceUnavailable, nil, &calls))
+	c.Retry = RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond}
+
+	err := c.GetAndUnmarshal("v2/orders", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v, want a 503 APIError", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want the first attempt and 2 retries", calls)
+	}
+}
+
+func TestRetryZeroPolicy(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, failTimes(1, http.StatusTooManyRequests, nil, &calls))
+	c.Retry = RetryPolicy{}
+
+	err := c.GetAndUnmarshal("v2/orders", nil)
+	if err == nil || calls != 1 {
+		t.Errorf("err = %v, calls = %d, a zero policy must not retry", err, calls)
+	}
+}
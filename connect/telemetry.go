@@ -0,0 +1,190 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName is the name the tracer and meter are created under
+const instrumentationName = "github.com/dan-collins/biggommerce"
+
+// Telemetry is the OpenTelemetry tracer and instruments a BCClient reports to. Every request DoRequest sends is a
+// span, a child of whatever span is on the request context, with a client span under it for each attempt so retries
+// show up as siblings. The batch helpers of the resource clients start a parent span for the requests they fan out.
+//
+// The instruments are
+//   - bigcommerce.client.requests, a counter of finished requests
+//   - bigcommerce.client.request.duration, a histogram of request latency in seconds including retries
+//   - bigcommerce.client.rate_limit.remaining, a gauge of the X-Rate-Limit-Requests-Left BigCommerce last reported
+//
+// the first two are broken down by method, endpoint template and status, the gauge by store key. A client without
+// Telemetry reports to the global providers, so nothing is recorded until otel.SetTracerProvider and
+// otel.SetMeterProvider are called. Point a client at the SDK's in-memory exporter and manual reader to test with it
+//
+//	exporter := tracetest.NewInMemoryExporter()
+//	reader := sdkmetric.NewManualReader()
+//	client.Telemetry, err = connect.NewTelemetry(
+//		sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+//		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+//	)
+type Telemetry struct {
+	tracer    trace.Tracer
+	requests  metric.Int64Counter
+	duration  metric.Float64Histogram
+	remaining metric.Int64Gauge
+}
+
+// NewTelemetry creates the tracer and instruments from the providers passed in, a nil provider falls back to the
+// global one
+func NewTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) (*Telemetry, error) {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(instrumentationName)
+	t := &Telemetry{tracer: tp.Tracer(instrumentationName)}
+	var err error
+	t.requests, err = meter.Int64Counter("bigcommerce.client.requests",
+		metric.WithDescription("Requests sent to the BigCommerce API"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+	t.duration, err = meter.Float64Histogram("bigcommerce.client.request.duration",
+		metric.WithDescription("Duration of requests to the BigCommerce API, retries included"),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, err
+	}
+	t.remaining, err = meter.Int64Gauge("bigcommerce.client.rate_limit.remaining",
+		metric.WithDescription("Requests left in the current rate limit window of the store"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+var (
+	globalTelemetryOnce sync.Once
+	globalTelemetry     *Telemetry
+)
+
+// telemetry returns the client's Telemetry or the one reporting to the global providers
+func (s *BCClient) telemetry() *Telemetry {
+	if s.Telemetry != nil {
+		return s.Telemetry
+	}
+	globalTelemetryOnce.Do(func() {
+		var err error
+		globalTelemetry, err = NewTelemetry(nil, nil)
+		if err != nil {
+			globalTelemetry, _ = NewTelemetry(tracenoop.NewTracerProvider(), metricnoop.NewMeterProvider())
+		}
+	})
+	return globalTelemetry
+}
+
+// StartSpan starts a span as a child of any span on ctx, the requests made with the returned context become its
+// children. It is what the batch helpers use to group the requests they fan out, end it with EndSpan
+func (s *BCClient) StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return s.telemetry().tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err on span, if there is one, and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// startRequest starts the span of a request, the request returned carries it on its context
+func (s *BCClient) startRequest(req *http.Request) (*http.Request, trace.Span) {
+	endpoint := EndpointTemplate(req.URL.Path)
+	ctx, span := s.telemetry().tracer.Start(req.Context(), req.Method+" "+endpoint,
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.String()),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.String("bigcommerce.endpoint", endpoint),
+		))
+	return req.WithContext(ctx), span
+}
+
+// endRequest ends the span of a request and records its metrics
+func (s *BCClient) endRequest(req *http.Request, span trace.Span, resp *http.Response, retries int, duration time.Duration, err error) {
+	t := s.telemetry()
+	ctx := req.Context()
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("bigcommerce.endpoint", EndpointTemplate(req.URL.Path)),
+	}
+	span.SetAttributes(attribute.Int("bigcommerce.retries", retries))
+	if resp != nil {
+		status := attribute.Int("http.response.status_code", resp.StatusCode)
+		attrs = append(attrs, status)
+		span.SetAttributes(status)
+		if left, convErr := strconv.ParseInt(resp.Header.Get("X-Rate-Limit-Requests-Left"), 10, 64); convErr == nil {
+			span.SetAttributes(attribute.Int64("bigcommerce.rate_limit.remaining", left))
+			t.remaining.Record(ctx, left, metric.WithAttributes(attribute.String("bigcommerce.store", s.StoreKey)))
+		}
+		if id := resp.Header.Get("X-Request-ID"); id != "" {
+			span.SetAttributes(attribute.String("bigcommerce.request_id", id))
+		}
+	}
+	t.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+	t.duration.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs...))
+	EndSpan(span, err)
+}
+
+// startAttempt starts the client span of one attempt at sending req, a child of the request's span. Retries carry
+// the number of the retry as http.request.resend_count
+func (s *BCClient) startAttempt(req *http.Request, attempt int) (*http.Request, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.request.method", req.Method),
+		attribute.String("url.full", req.URL.String()),
+		attribute.String("server.address", req.URL.Hostname()),
+	}
+	if attempt > 0 {
+		attrs = append(attrs, attribute.Int("http.request.resend_count", attempt))
+	}
+	ctx, span := s.telemetry().tracer.Start(req.Context(), req.Method+" "+EndpointTemplate(req.URL.Path),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	return req.WithContext(ctx), span
+}
+
+// endAttempt ends the span of an attempt, a response of 400 or above marks it as failed even when it is retried
+func endAttempt(span trace.Span, resp *http.Response, err error) {
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= 400 {
+			span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+		}
+	}
+	EndSpan(span, err)
+}
+
+// spanRetry notes on the request's span that an attempt is being retried
+func spanRetry(req *http.Request, status int, attempt int, delay time.Duration) {
+	trace.SpanFromContext(req.Context()).AddEvent("retry", trace.WithAttributes(
+		attribute.Int("http.response.status_code", status),
+		attribute.Int("bigcommerce.attempt", attempt),
+		attribute.String("bigcommerce.retry_delay", delay.String()),
+	))
+}
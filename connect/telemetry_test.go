@@ -0,0 +1,167 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTracedClient returns a test client reporting to an in-memory span exporter and a manual metric reader
+func newTracedClient(t *testing.T, handler http.HandlerFunc) (*BCClient, *tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	t.Helper()
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+	c := newTestClient(t, handler)
+	var err error
+	c.Telemetry, err = NewTelemetry(
+		sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, exporter, reader
+}
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTelemetryRequestSpan(t *testing.T) {
+	c, exporter, reader := newTracedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Rate-Limit-Requests-Left", "149")
+		w.Header().Set("X-Request-ID", "req-1")
+		w.Write([]byte("{}"))
+	})
+
+	err := c.GetAndUnmarshal("v2/orders/123/products", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want the request and its one attempt", len(spans))
+	}
+	attempt, request := spans[0], spans[1]
+	if request.Name != "GET v2/orders/{id}/products" || attempt.Name != request.Name {
+		t.Errorf("spans are named %q and %q, want the method and endpoint template", request.Name, attempt.Name)
+	}
+	if attempt.Parent.SpanID() != request.SpanContext.SpanID() || attempt.SpanKind != trace.SpanKindClient {
+		t.Error("the attempt should be a client span under the request span")
+	}
+	if request.Status.Code == codes.Error || attempt.Status.Code == codes.Error {
+		t.Error("a successful request should not be marked as failed")
+	}
+	for key, want := range map[attribute.Key]attribute.Value{
+		"http.request.method":              attribute.StringValue("GET"),
+		"bigcommerce.endpoint":             attribute.StringValue("v2/orders/{id}/products"),
+		"http.response.status_code":        attribute.IntValue(http.StatusOK),
+		"bigcommerce.retries":              attribute.IntValue(0),
+		"bigcommerce.rate_limit.remaining": attribute.Int64Value(149),
+		"bigcommerce.request_id":           attribute.StringValue("req-1"),
+	} {
+		if got, ok := spanAttr(request, key); !ok || got != want {
+			t.Errorf("request span %s = %v, want %v", key, got.Emit(), want.Emit())
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			found[m.Name] = true
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				if len(data.DataPoints) != 1 || data.DataPoints[0].Value != 1 {
+					t.Errorf("%s = %+v, want a single request", m.Name, data.DataPoints)
+				}
+			case metricdata.Gauge[int64]:
+				if len(data.DataPoints) != 1 || data.DataPoints[0].Value != 149 {
+					t.Errorf("%s = %+v, want 149", m.Name, data.DataPoints)
+				}
+			}
+		}
+	}
+	for _, name := range []string{"bigcommerce.client.requests", "bigcommerce.client.request.duration", "bigcommerce.client.rate_limit.remaining"} {
+		if !found[name] {
+			t.Errorf("metric %s was not recorded", name)
+		}
+	}
+}
+
+func TestTelemetryRetriedRequest(t *testing.T) {
+	var calls int32
+	c, exporter, _ := newTracedClient(t, failTimes(2, http.StatusServiceUnavailable, nil, &calls))
+	c.Retry = RetryPolicy{MaxRetries: 2}
+
+	err := c.GetAndUnmarshal("v2/orders", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 4 {
+		t.Fatalf("got %d spans, want 3 attempts and the request", len(spans))
+	}
+	request := spans[3]
+	for i, attempt := range spans[:3] {
+		if attempt.Parent.SpanID() != request.SpanContext.SpanID() {
+			t.Errorf("attempt %d is not a child of the request span", i)
+		}
+		count, ok := spanAttr(attempt, "http.request.resend_count")
+		if i == 0 && ok || i > 0 && count.AsInt64() != int64(i) {
+			t.Errorf("attempt %d has resend count %v", i, count.Emit())
+		}
+		failed := attempt.Status.Code == codes.Error
+		if failed != (i < 2) {
+			t.Errorf("attempt %d failed = %v, only the 503s should be errors", i, failed)
+		}
+	}
+	if retries, _ := spanAttr(request, "bigcommerce.retries"); retries.AsInt64() != 2 || request.Status.Code == codes.Error {
+		t.Errorf("request span has %d retries and status %v, want 2 and ok", retries.AsInt64(), request.Status.Code)
+	}
+	if len(request.Events) != 2 || request.Events[0].Name != "retry" {
+		t.Errorf("request span has events %v, want a retry event for each retry", request.Events)
+	}
+}
+
+func TestTelemetryServerError(t *testing.T) {
+	var calls int32
+	c, exporter, _ := newTracedClient(t, failTimes(10, http.StatusBadGateway, nil, &calls))
+	c.Retry = RetryPolicy{MaxRetries: 1}
+
+	err := c.GetAndUnmarshal("v2/orders", nil)
+	if err == nil {
+		t.Fatal("a 502 that outlasts the retries should fail the request")
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 2 attempts and the request", len(spans))
+	}
+	for _, span := range spans {
+		if span.Status.Code != codes.Error {
+			t.Errorf("span %s has status %v, want an error", span.Name, span.Status.Code)
+		}
+		if status, _ := spanAttr(span, "http.response.status_code"); status.AsInt64() != http.StatusBadGateway {
+			t.Errorf("span %s has status code %d, want 502", span.Name, status.AsInt64())
+		}
+	}
+	if request := spans[2]; len(request.Events) == 0 || request.Events[len(request.Events)-1].Name != "exception" {
+		t.Error("the error should be recorded on the request span")
+	}
+}
@@ -0,0 +1,85 @@
+package connect
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps the transport requests are sent through, it is used for cross cutting concerns like logging,
+// metrics or header injection. See BCClient.Use
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc lets a plain function be used as an http.RoundTripper, handy when writing a Middleware
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// defaultTransport keeps enough idle connections to BigCommerce around for a whole fan-out to reuse them, the
+// standard library default of 2 per host means most of a 20 wide fan-out reconnects on every request
+var defaultTransport http.RoundTripper = &http.Transport{
+	Proxy: http.ProxyFromEnvironment,
+	DialContext: (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext,
+	ForceAttemptHTTP2:     true,
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   DefaultConcurrency * 2,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ExpectContinueTimeout: time.Second,
+}
+
+// defaultHTTPClient is shared by every BCClient without its own HTTPClient
+var defaultHTTPClient = &http.Client{
+	Transport: defaultTransport,
+	Timeout:   2 * time.Minute,
+}
+
+// Use adds middleware to the client, the first middleware added is the first to see each request
+func (s *BCClient) Use(middleware ...Middleware) {
+	s.Middleware = append(append([]Middleware(nil), s.Middleware...), middleware...)
+}
+
+// httpClient is the client requests are sent with: HTTPClient (or the shared default) with its transport replaced by
+// Transport when set and wrapped in the Middleware
+func (s *BCClient) httpClient() *http.Client {
+	base := s.HTTPClient
+	if base == nil {
+		base = defaultHTTPClient
+	}
+	if s.Transport == nil && len(s.Middleware) == 0 {
+		return base
+	}
+	transport := s.Transport
+	if transport == nil {
+		transport = base.Transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(s.Middleware) - 1; i >= 0; i-- {
+		transport = s.Middleware[i](transport)
+	}
+	client := *base
+	client.Transport = transport
+	return &client
+}
+
+// HeaderMiddleware sets the headers on every request, replacing any value already there
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			// a RoundTripper must not change the request it was given
+			req = req.Clone(req.Context())
+			for k, v := range header {
+				req.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package connect
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// traced is a middleware that logs when a request passes it on the way out and the response on the way back
+func traced(name string, mu *sync.Mutex, trace *[]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			*trace = append(*trace, name+" "+req.Header.Get("X-Source"))
+			mu.Unlock()
+			resp, err := next.RoundTrip(req)
+			mu.Lock()
+			*trace = append(*trace, name+" done")
+			mu.Unlock()
+			return resp, err
+		})
+	}
+}
+
+func TestMiddlewareChain(t *testing.T) {
+	var got http.Header
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	})
+	var mu sync.Mutex
+	var trace []string
+	c.Use(traced("outer", &mu, &trace), HeaderMiddleware(http.Header{"x-source": {"sync"}, "X-Auth-Client": {"other"}}))
+	c.Use(traced("inner", &mu, &trace))
+
+	var out struct{}
+	if err := c.GetAndUnmarshal("v2/orders/101", &out); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"outer ", "inner sync", "inner done", "outer done"}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("middleware ran as %q, want %q", trace, want)
+	}
+	if got.Get("X-Source") != "sync" {
+		t.Errorf("X-Source = %q, want the header the middleware set", got.Get("X-Source"))
+	}
+	if v := got.Values("X-Auth-Client"); len(v) != 1 || v[0] != "other" {
+		t.Errorf("X-Auth-Client = %q, want the middleware's value to replace the client's", v)
+	}
+	if got.Get("X-Auth-Token") != "token" {
+		t.Error("headers the middleware does not set should be sent as they were")
+	}
+}
+
+func TestHTTPClientPrecedence(t *testing.T) {
+	var used []string
+	named := func(name string) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			used = append(used, name)
+			return defaultTransport.RoundTrip(req)
+		})
+	}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	})
+	if c.httpClient() != defaultHTTPClient {
+		t.Error("a client without its own transport or middleware should use the shared client")
+	}
+
+	c.HTTPClient = &http.Client{Transport: named("http client"), Timeout: 5 * time.Second}
+	var out struct{}
+	if err := c.GetAndUnmarshal("v2/orders/101", &out); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Transport = named("transport")
+	c.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			used = append(used, "middleware")
+			return next.RoundTrip(req)
+		})
+	})
+	if err := c.GetAndUnmarshal("v2/orders/101", &out); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"http client", "middleware", "transport"}
+	if !reflect.DeepEqual(used, want) {
+		t.Errorf("requests went through %q, want %q", used, want)
+	}
+	if client := c.httpClient(); client.Timeout != 5*time.Second || client == c.HTTPClient {
+		t.Error("Transport should replace the transport on a copy of HTTPClient, keeping its other settings")
+	}
+
+	c.Transport = nil
+	used = nil
+	if err := c.GetAndUnmarshal("v2/orders/101", &out); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"middleware", "http client"}; !reflect.DeepEqual(used, want) {
+		t.Errorf("requests went through %q, want the middleware to wrap HTTPClient's own transport", used)
+	}
+}
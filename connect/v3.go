@@ -0,0 +1,171 @@
+package connect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Envelope is the wrapper V3 endpoints put around their results, e.g. {"data": [...], "meta": {"pagination": {...}}}
+type Envelope struct {
+	Data json.RawMessage `json:"data"`
+	Meta Meta            `json:"meta"`
+}
+
+// Meta is the meta object of a V3 response, only paged endpoints fill Pagination
+type Meta struct {
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Pagination is the offset pagination block returned by paged V3 endpoints
+type Pagination struct {
+	Total       int             `json:"total"`
+	Count       int             `json:"count"`
+	PerPage     int             `json:"per_page"`
+	CurrentPage int             `json:"current_page"`
+	TotalPages  int             `json:"total_pages"`
+	Links       PaginationLinks `json:"links"`
+}
+
+// PaginationLinks are the query strings (e.g. "?page=2&limit=50") of the pages around the current one
+type PaginationLinks struct {
+	Previous string `json:"previous,omitempty"`
+	Current  string `json:"current,omitempty"`
+	Next     string `json:"next,omitempty"`
+}
+
+// UnmarshalEnvelope unmarshals the data of a V3 response body into outData and returns its meta
+func UnmarshalEnvelope(body []byte, outData interface{}) (*Meta, error) {
+	var env Envelope
+	if len(body) == 0 {
+		return &env.Meta, nil
+	}
+	err := json.Unmarshal(body, &env)
+	if err != nil {
+		return nil, err
+	}
+	if outData != nil && len(env.Data) > 0 {
+		err = json.Unmarshal(env.Data, outData)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &env.Meta, nil
+}
+
+// DoV3Context - sends inData (if not nil) to a V3 endpoint with the given method, unwraps the envelope of the response
+// into outData (if not nil) and returns its meta
+//
+// Example of the endpoint parameter would be "v3/orders/12039/transactions" and the client will handle the store key
+// and base url pieces
+func (s *BCClient) DoV3Context(ctx context.Context, method string, endpoint string, rawQuery string, inData interface{}, outData interface{}) (*Meta, error) {
+	req, err := s.BuildRequestContext(ctx, method, endpoint, inData)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = rawQuery
+	body, err := s.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalEnvelope(body, outData)
+}
+
+// GetV3AndUnmarshal - gets a single page of a V3 endpoint and unmarshals its data to passed in struct pointer
+func (s *BCClient) GetV3AndUnmarshal(endpoint string, rawQuery string, outData interface{}) (*Meta, error) {
+	return s.GetV3AndUnmarshalContext(context.Background(), endpoint, rawQuery, outData)
+}
+
+// GetV3AndUnmarshalContext - same as GetV3AndUnmarshal but the request is cancelled when ctx is done
+func (s *BCClient) GetV3AndUnmarshalContext(ctx context.Context, endpoint string, rawQuery string, outData interface{}) (*Meta, error) {
+	return s.DoV3Context(ctx, "GET", endpoint, rawQuery, nil, outData)
+}
+
+// WalkPages - calls fn with the raw data of every page of a V3 endpoint, starting from the page in rawQuery (or the
+// first page) and following meta.pagination until the last one. Returning an error from fn stops the walk
+func (s *BCClient) WalkPages(endpoint string, rawQuery string, fn func(data json.RawMessage, p Pagination) error) error {
+	return s.WalkPagesContext(context.Background(), endpoint, rawQuery, fn)
+}
+
+// WalkPagesContext - same as WalkPages, cancelling ctx stops fetching any further pages
+func (s *BCClient) WalkPagesContext(ctx context.Context, endpoint string, rawQuery string, fn func(data json.RawMessage, p Pagination) error) error {
+	for {
+		var data json.RawMessage
+		meta, err := s.GetV3AndUnmarshalContext(ctx, endpoint, rawQuery, &data)
+		if err != nil {
+			return err
+		}
+		var p Pagination
+		if meta.Pagination != nil {
+			p = *meta.Pagination
+		}
+		err = fn(data, p)
+		if err != nil {
+			return err
+		}
+		if meta.Pagination == nil {
+			return nil
+		}
+		next, ok, err := nextPageQuery(rawQuery, p)
+		if err != nil || !ok {
+			return err
+		}
+		rawQuery = next
+	}
+}
+
+// GetAllPages - walks every page of a V3 endpoint and appends the data of each to the slice outSlice points at
+//
+// Example: var products []catalog.Product; err := s.GetAllPages("v3/catalog/products", "limit=250", &products)
+func (s *BCClient) GetAllPages(endpoint string, rawQuery string, outSlice interface{}) error {
+	return s.GetAllPagesContext(context.Background(), endpoint, rawQuery, outSlice)
+}
+
+// GetAllPagesContext - same as GetAllPages, cancelling ctx stops fetching any further pages
+func (s *BCClient) GetAllPagesContext(ctx context.Context, endpoint string, rawQuery string, outSlice interface{}) error {
+	out := reflect.ValueOf(outSlice)
+	if out.Kind() != reflect.Ptr || out.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("GetAllPages needs a pointer to a slice, got %T", outSlice)
+	}
+	all := out.Elem()
+	return s.WalkPagesContext(ctx, endpoint, rawQuery, func(data json.RawMessage, p Pagination) error {
+		page := reflect.New(all.Type())
+		if len(data) > 0 {
+			err := json.Unmarshal(data, page.Interface())
+			if err != nil {
+				return err
+			}
+		}
+		all.Set(reflect.AppendSlice(all, page.Elem()))
+		return nil
+	})
+}
+
+// nextPageQuery works out the query of the page after p. BigCommerce hands back the next page as a query string in
+// links.next, which is merged over the current query so filters are kept; when it is missing we fall back to
+// counting up to total_pages
+func nextPageQuery(rawQuery string, p Pagination) (string, bool, error) {
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", false, err
+	}
+	if p.Links.Next != "" {
+		next, err := url.ParseQuery(strings.TrimPrefix(p.Links.Next, "?"))
+		if err != nil {
+			return "", false, err
+		}
+		for k, v := range next {
+			values[k] = v
+		}
+		return values.Encode(), true, nil
+	}
+	if p.CurrentPage <= 0 || p.CurrentPage >= p.TotalPages {
+		return "", false, nil
+	}
+	values.Set("page", strconv.Itoa(p.CurrentPage+1))
+	return values.Encode(), true, nil
+}
@@ -0,0 +1,150 @@
+package connect
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+type v3Item struct {
+	ID int `json:"id"`
+}
+
+// pagedServer serves n items two to a page the way V3 list endpoints do, sending links.next when withLinks is set
+// and only total_pages otherwise. It returns the query of every request it got
+func pagedServer(t *testing.T, n int, withLinks bool) (*BCClient, func() []url.Values) {
+	t.Helper()
+	var mu sync.Mutex
+	var queries []url.Values
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		mu.Lock()
+		queries = append(queries, q)
+		mu.Unlock()
+		page, _ := strconv.Atoi(q.Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		limit := 2
+		totalPages := (n + limit - 1) / limit
+		var items []v3Item
+		for id := (page-1)*limit + 1; id <= n && id <= page*limit; id++ {
+			items = append(items, v3Item{ID: id})
+		}
+		p := Pagination{Total: n, Count: len(items), PerPage: limit, CurrentPage: page, TotalPages: totalPages}
+		if withLinks && page < totalPages {
+			p.Links.Next = fmt.Sprintf("?page=%d&limit=%d", page+1, limit)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"data": items, "meta": Meta{Pagination: &p}})
+	})
+	return c, func() []url.Values {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]url.Values(nil), queries...)
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	for _, withLinks := range []bool{true, false} {
+		c, queries := pagedServer(t, 5, withLinks)
+
+		var items []v3Item
+		err := c.GetAllPages("v3/catalog/products", "keyword=mug&include=variants", &items)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(items) != 5 || items[0].ID != 1 || items[4].ID != 5 {
+			t.Errorf("links %v: got %v, want items 1 to 5 in order", withLinks, items)
+		}
+		got := queries()
+		if len(got) != 3 {
+			t.Fatalf("links %v: walked %d pages, want 3", withLinks, len(got))
+		}
+		for i, q := range got[1:] {
+			if q.Get("page") != strconv.Itoa(i+2) || q.Get("keyword") != "mug" || q.Get("include") != "variants" {
+				t.Errorf("links %v: page %d was asked for with %v, want the filters of the first page kept", withLinks, i+2, q)
+			}
+		}
+	}
+}
+
+func TestWalkPagesStopsOnError(t *testing.T) {
+	c, queries := pagedServer(t, 10, true)
+	stop := errors.New("seen enough")
+
+	pages := 0
+	err := c.WalkPages("v3/catalog/products", "", func(data json.RawMessage, p Pagination) error {
+		pages++
+		if p.CurrentPage == 2 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("err = %v, want the error of the callback", err)
+	}
+	if pages != 2 || len(queries()) != 2 {
+		t.Errorf("walked %d pages with %d requests, want it to stop at page 2", pages, len(queries()))
+	}
+}
+
+func TestWalkPagesStartsAtPage(t *testing.T) {
+	c, queries := pagedServer(t, 5, false)
+
+	var items []v3Item
+	err := c.GetAllPages("v3/catalog/products", "page=2", &items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 3 || items[0].ID != 3 || len(queries()) != 2 {
+		t.Errorf("got %v in %d requests, want items 3 to 5 from the last two pages", items, len(queries()))
+	}
+	if err := c.GetAllPages("v3/catalog/products", "", items); err == nil {
+		t.Error("GetAllPages should refuse anything but a pointer to a slice")
+	}
+}
+
+func TestNextPageQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		p     Pagination
+		want  string
+		more  bool
+	}{
+		{"link", "keyword=mug&page=1", Pagination{CurrentPage: 1, TotalPages: 3, Links: PaginationLinks{Next: "?page=2&limit=50"}}, "keyword=mug&limit=50&page=2", true},
+		{"counted", "keyword=mug", Pagination{CurrentPage: 1, TotalPages: 3}, "keyword=mug&page=2", true},
+		{"last page", "page=3", Pagination{CurrentPage: 3, TotalPages: 3}, "", false},
+		{"no pagination", "", Pagination{}, "", false},
+	}
+	for _, tt := range tests {
+		got, more, err := nextPageQuery(tt.query, tt.p)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want || more != tt.more {
+			t.Errorf("%s: next page is %q (%v), want %q (%v)", tt.name, got, more, tt.want, tt.more)
+		}
+	}
+}
+
+func TestUnmarshalEnvelope(t *testing.T) {
+	var items []v3Item
+	meta, err := UnmarshalEnvelope([]byte(`{"data":[{"id":7}],"meta":{"pagination":{"total":1,"current_page":1,"total_pages":1}}}`), &items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 1 || items[0].ID != 7 || meta.Pagination == nil || meta.Pagination.Total != 1 {
+		t.Errorf("got %v and %+v", items, meta)
+	}
+	meta, err = UnmarshalEnvelope(nil, &items)
+	if err != nil || meta == nil || meta.Pagination != nil {
+		t.Errorf("an empty body, like a 204, should give an empty meta, got %+v, %v", meta, err)
+	}
+}
@@ -0,0 +1,77 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dan-collins/biggommerce/connect"
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+// Client is a wrapper struct that embeds the BCClient from the client package. It handles connection to the BigCommerce API
+type Client struct {
+	connect.BCClient
+}
+
+// NewClient will create a new currency client wrapper based on BC connection details
+func NewClient(authToken, authClient, storeKey string) *Client {
+	bcClient := connect.NewClient(authToken, authClient, storeKey)
+	currencyClient := Client{}
+	currencyClient.BCClient = *bcClient
+	return &currencyClient
+}
+
+// GetCurrencies will return the currencies configured for the store
+func (s *Client) GetCurrencies() (Currencies, error) {
+	return s.GetCurrenciesContext(context.Background())
+}
+
+// GetCurrenciesContext - same as GetCurrencies but the request is cancelled when ctx is done
+func (s *Client) GetCurrenciesContext(ctx context.Context) (Currencies, error) {
+	var data Currencies
+	err := s.GetAndUnmarshalContext(ctx, "v2/currencies", &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Default returns the store's default currency, all exchange rates are relative to it
+func (cs Currencies) Default() (Currency, bool) {
+	for _, c := range cs {
+		if c.IsDefault {
+			return c, true
+		}
+	}
+	return Currency{}, false
+}
+
+// ByCode returns the currency with the ISO 4217 code
+func (cs Currencies) ByCode(code string) (Currency, bool) {
+	for _, c := range cs {
+		if strings.EqualFold(c.CurrencyCode, code) {
+			return c, true
+		}
+	}
+	return Currency{}, false
+}
+
+// Convert converts amount between two of the store's currencies using their exchange rates to the default currency,
+// the result is rounded half up to the decimal places of the target currency
+func (cs Currencies) Convert(amount primative.Money, fromCode, toCode string) (primative.Money, error) {
+	from, ok := cs.ByCode(fromCode)
+	if !ok {
+		return primative.Money{}, fmt.Errorf("currency: %s is not configured for the store", fromCode)
+	}
+	to, ok := cs.ByCode(toCode)
+	if !ok {
+		return primative.Money{}, fmt.Errorf("currency: %s is not configured for the store", toCode)
+	}
+	if from.CurrencyExchangeRate.IsZero() {
+		return primative.Money{}, fmt.Errorf("currency: %s has no exchange rate", from.CurrencyCode)
+	}
+	// exchange rates are how much of the currency one unit of the default currency buys
+	converted := amount.Mul(to.CurrencyExchangeRate)
+	return converted.Div(from.CurrencyExchangeRate, int32(to.DecimalPlaces), primative.RoundHalfUp), nil
+}
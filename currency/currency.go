@@ -0,0 +1,52 @@
+package currency
+
+import (
+	"strings"
+
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+// Currencies is a slice of structs that represent the currencies configured for a BigCommerce store
+type Currencies []Currency
+
+// Currency is a struct that represents the return body of BigCommerce GET /currencies
+type Currency struct {
+	ID                   int64            `json:"id,omitempty"`
+	IsDefault            bool             `json:"is_default,omitempty"`
+	DateCreated          primative.BCDate `json:"date_created,omitempty"`
+	DateModified         primative.BCDate `json:"date_modified,omitempty"`
+	LastUpdated          primative.BCDate `json:"last_updated,omitempty"`
+	CountryIso2          string           `json:"country_iso2,omitempty"`
+	CurrencyCode         string           `json:"currency_code,omitempty"`
+	CurrencyExchangeRate primative.Money  `json:"currency_exchange_rate"`
+	AutoUpdate           bool             `json:"auto_update,omitempty"`
+	TokenLocation        string           `json:"token_location,omitempty"`
+	Token                string           `json:"token,omitempty"`
+	DecimalToken         string           `json:"decimal_token,omitempty"`
+	ThousandsToken       string           `json:"thousands_token,omitempty"`
+	DecimalPlaces        int              `json:"decimal_places,omitempty"`
+	Name                 string           `json:"name,omitempty"`
+	IsTransactional      bool             `json:"is_transactional,omitempty"`
+	Enabled              bool             `json:"enabled,omitempty"`
+}
+
+// Format writes amount the way the store displays this currency, using its token, separators and decimal places
+func (c Currency) Format(amount primative.Money) string {
+	decimal := c.DecimalToken
+	if decimal == "" {
+		decimal = "."
+	}
+	number := amount.FormatNumber(int32(c.DecimalPlaces), c.ThousandsToken, decimal)
+	neg := strings.HasPrefix(number, "-")
+	number = strings.TrimPrefix(number, "-")
+
+	if c.TokenLocation == "right" {
+		number += c.Token
+	} else {
+		number = c.Token + number
+	}
+	if neg {
+		number = "-" + number
+	}
+	return number
+}
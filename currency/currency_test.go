@@ -0,0 +1,68 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+func TestCurrencyFormat(t *testing.T) {
+	euro := Currency{Token: " €", TokenLocation: "right", DecimalToken: ",", ThousandsToken: ".", DecimalPlaces: 2}
+	dollar := Currency{Token: "$", TokenLocation: "left", DecimalToken: ".", ThousandsToken: ",", DecimalPlaces: 2}
+	yen := Currency{Token: "¥", ThousandsToken: ",", DecimalPlaces: 0}
+	tests := []struct {
+		c      Currency
+		amount string
+		want   string
+	}{
+		{euro, "1234.5", "1.234,50 €"},
+		{euro, "-3.1", "-3,10 €"},
+		{dollar, "1234567.891", "$1,234,567.89"},
+		{dollar, "-0.5", "-$0.50"},
+		{yen, "1234.5", "¥1,235"},
+	}
+	for _, tt := range tests {
+		if got := tt.c.Format(primative.MustParseMoney(tt.amount)); got != tt.want {
+			t.Errorf("Format(%s) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestCurrenciesConvert(t *testing.T) {
+	cs := Currencies{
+		{CurrencyCode: "USD", IsDefault: true, CurrencyExchangeRate: primative.MustParseMoney("1"), DecimalPlaces: 2},
+		{CurrencyCode: "EUR", CurrencyExchangeRate: primative.MustParseMoney("0.9"), DecimalPlaces: 2},
+		{CurrencyCode: "JPY", CurrencyExchangeRate: primative.MustParseMoney("150"), DecimalPlaces: 0},
+		{CurrencyCode: "XXX", DecimalPlaces: 2},
+	}
+	tests := []struct {
+		amount   string
+		from, to string
+		want     string
+	}{
+		{"100", "USD", "EUR", "90.00"},
+		{"10", "EUR", "USD", "11.11"},
+		{"10", "eur", "jpy", "1667"},
+		{"1999", "JPY", "USD", "13.33"},
+		{"0.005", "USD", "USD", "0.01"},
+	}
+	for _, tt := range tests {
+		got, err := cs.Convert(primative.MustParseMoney(tt.amount), tt.from, tt.to)
+		if err != nil {
+			t.Errorf("Convert(%s %s to %s): %v", tt.amount, tt.from, tt.to, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("Convert(%s %s to %s) = %s, want %s", tt.amount, tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	for _, codes := range [][2]string{{"GBP", "USD"}, {"USD", "GBP"}, {"XXX", "USD"}} {
+		if _, err := cs.Convert(primative.MustParseMoney("1"), codes[0], codes[1]); err == nil {
+			t.Errorf("converting %s to %s should fail", codes[0], codes[1])
+		}
+	}
+	if d, ok := cs.Default(); !ok || d.CurrencyCode != "USD" {
+		t.Errorf("default currency is %s, want USD", d.CurrencyCode)
+	}
+}
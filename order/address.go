@@ -17,8 +17,10 @@ type Address struct {
 	FormFields  []FormField `json:"form_fields,omitempty"`
 }
 
-// ShippingAddress is a struct that represents a BigCommerce shipping address resource
+// ShippingAddress is a struct that represents a BigCommerce shipping address resource, set ID to change an existing
+// address of an order
 type ShippingAddress struct {
+	ID int64 `json:"id,omitempty"`
 	Address
 	ShippingMethod string `json:"shipping_method"`
 }
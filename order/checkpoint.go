@@ -0,0 +1,102 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// Checkpoint is how far a Syncer has got, the high-water mark of the orders it handed out and the versions it handed
+// out inside the overlap window, so they are not handed out again
+type Checkpoint struct {
+	// DateModified is the latest date modified handed out
+	DateModified time.Time `json:"date_modified"`
+	// ID is the order that was handed out at DateModified, the highest id if there were several
+	ID int64 `json:"id"`
+	// Seen is the date modified of every order handed out within the overlap window, by order id
+	Seen map[int64]time.Time `json:"seen,omitempty"`
+}
+
+// CheckpointStore keeps the Checkpoint of a Syncer between runs
+type CheckpointStore interface {
+	// Load returns the saved checkpoint, a zero Checkpoint when nothing was saved yet
+	Load(ctx context.Context) (Checkpoint, error)
+	// Save replaces the saved checkpoint
+	Save(ctx context.Context, cp Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory, for tests and processes that sync from a fixed point every
+// time they start. It is safe for concurrent use
+type MemoryCheckpointStore struct {
+	mu sync.Mutex
+	cp Checkpoint
+}
+
+// Load returns a copy of the checkpoint last saved
+func (m *MemoryCheckpointStore) Load(ctx context.Context) (Checkpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return copyCheckpoint(m.cp), nil
+}
+
+// Save keeps a copy of cp
+func (m *MemoryCheckpointStore) Save(ctx context.Context, cp Checkpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cp = copyCheckpoint(cp)
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint in a json file, replaced atomically on every save
+type FileCheckpointStore struct {
+	Path string
+}
+
+// NewFileCheckpointStore returns a store for the checkpoint file at path, the file is created on the first save
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{Path: path}
+}
+
+// Load reads the checkpoint file, a missing file is a zero Checkpoint
+func (f *FileCheckpointStore) Load(ctx context.Context) (Checkpoint, error) {
+	var cp Checkpoint
+	data, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	}
+	if err != nil {
+		return cp, err
+	}
+	err = json.Unmarshal(data, &cp)
+	if err != nil {
+		return cp, fmt.Errorf("order: checkpoint %s: %w", f.Path, err)
+	}
+	return cp, nil
+}
+
+// Save writes cp to a temporary file next to Path and renames it over Path
+func (f *FileCheckpointStore) Save(ctx context.Context, cp Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp := f.Path + ".tmp"
+	err = ioutil.WriteFile(tmp, data, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
+
+func copyCheckpoint(cp Checkpoint) Checkpoint {
+	seen := make(map[int64]time.Time, len(cp.Seen))
+	for id, modified := range cp.Seen {
+		seen[id] = modified
+	}
+	cp.Seen = seen
+	return cp
+}
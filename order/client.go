@@ -1,12 +1,16 @@
 package order
 
 import (
+	"context"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/dan-collins/biggommerce/connect"
 	"github.com/google/go-querystring/query"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -24,58 +28,112 @@ func NewClient(authToken, authClient, storeKey string) *Client {
 	return &orderClient
 }
 
-// GetProductDetail - Will attempt to concurrently fill the order slice elements with their respective products from the BC api
-func (s *Client) GetProductDetail(os []Order) (err error) {
-	var eg errgroup.Group
-	sem := make(chan bool, 20)
+// forEachOrder runs fn concurrently for every order in os, at most the client's Concurrency at a time.
+//
+// The first error cancels the context handed to the other calls, and no new calls are started once ctx is done
+func (s *Client) forEachOrder(ctx context.Context, os []Order, fn func(ctx context.Context, o *Order) error) error {
+	return s.forEachOrderN(ctx, os, s.Concurrency, fn)
+}
+
+// forEachOrderN is forEachOrder with its own concurrency, the client's when 0
+func (s *Client) forEachOrderN(ctx context.Context, os []Order, concurrency int, fn func(ctx context.Context, o *Order) error) error {
+	if concurrency <= 0 {
+		concurrency = s.Concurrency
+	}
+	if concurrency <= 0 {
+		concurrency = connect.DefaultConcurrency
+	}
+	eg, egCtx := errgroup.WithContext(ctx)
+	sem := make(chan bool, concurrency)
 	for i := range os {
-		j := i
+		select {
+		case sem <- true:
+		case <-egCtx.Done():
+			// a running call failed or the caller gave up, either way Wait has the answer
+			return waitForOrders(ctx, eg)
+		}
+		o := &os[i]
 		eg.Go(func() error {
-			sem <- true
 			defer func() { <-sem }()
-			return os[j].ProductResource.EagerGet(s, &os[j].Products)
+			return fn(egCtx, o)
 		})
 	}
-	err = eg.Wait()
-	return
+	return waitForOrders(ctx, eg)
+}
+
+func waitForOrders(ctx context.Context, eg *errgroup.Group) error {
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// startBatch starts the parent span of a batch helper, the requests it fans out become its children
+func (s *Client) startBatch(ctx context.Context, name string, orders int) (context.Context, trace.Span) {
+	return s.StartSpan(ctx, "order."+name, attribute.Int("bigcommerce.orders", orders))
+}
+
+// batchOrders records how many orders a batch that started with a query ended up working on
+func batchOrders(span trace.Span, orders int) {
+	span.SetAttributes(attribute.Int("bigcommerce.orders", orders))
+}
+
+// GetProductDetail - Will attempt to concurrently fill the order slice elements with their respective products from the BC api
+func (s *Client) GetProductDetail(os []Order) (err error) {
+	return s.GetProductDetailContext(context.Background(), os)
+}
+
+// GetProductDetailContext - same as GetProductDetail, cancelling ctx stops any outstanding requests
+func (s *Client) GetProductDetailContext(ctx context.Context, os []Order) (err error) {
+	ctx, span := s.startBatch(ctx, "GetProductDetail", len(os))
+	defer func() { connect.EndSpan(span, err) }()
+	return s.forEachOrder(ctx, os, func(ctx context.Context, o *Order) error {
+		products, err := s.GetOrderProductsContext(ctx, int(o.ID))
+		if err != nil {
+			return err
+		}
+		o.Products = products
+		return nil
+	})
 }
 
 // GetShippingAddressesForOrders - Will attempt to concurrently fill the order slice elements with their respective shipping addresses from the BC api
 func (s *Client) GetShippingAddressesForOrders(os []Order) (err error) {
-	var eg errgroup.Group
-	sem := make(chan bool, 20)
-	for i := range os {
-		j := i
-		eg.Go(func() error {
-			sem <- true
-			defer func() { <-sem }()
-			return os[j].ShippingResource.EagerGet(s, &os[j].ShippingAddresses)
-		})
-	}
-	err = eg.Wait()
-	return
+	return s.GetShippingAddressesForOrdersContext(context.Background(), os)
+}
+
+// GetShippingAddressesForOrdersContext - same as GetShippingAddressesForOrders, cancelling ctx stops any outstanding requests
+func (s *Client) GetShippingAddressesForOrdersContext(ctx context.Context, os []Order) (err error) {
+	ctx, span := s.startBatch(ctx, "GetShippingAddressesForOrders", len(os))
+	defer func() { connect.EndSpan(span, err) }()
+	return s.forEachOrder(ctx, os, func(ctx context.Context, o *Order) error {
+		return o.ShippingResource.EagerGetContext(ctx, s, &o.ShippingAddresses)
+	})
 }
 
 // GetCouponsForOrders - Will attempt to concurrently fill the order slice elements with their respective coupon objects from the BC api
 func (s *Client) GetCouponsForOrders(os []Order) (err error) {
-	var eg errgroup.Group
-	sem := make(chan bool, 20)
-	for i := range os {
-		j := i
-		eg.Go(func() error {
-			sem <- true
-			defer func() { <-sem }()
-			return os[j].CouponResource.EagerGet(s, &os[j].Coupons)
-		})
-	}
-	err = eg.Wait()
-	return
+	return s.GetCouponsForOrdersContext(context.Background(), os)
+}
+
+// GetCouponsForOrdersContext - same as GetCouponsForOrders, cancelling ctx stops any outstanding requests
+func (s *Client) GetCouponsForOrdersContext(ctx context.Context, os []Order) (err error) {
+	ctx, span := s.startBatch(ctx, "GetCouponsForOrders", len(os))
+	defer func() { connect.EndSpan(span, err) }()
+	return s.forEachOrder(ctx, os, func(ctx context.Context, o *Order) error {
+		return o.CouponResource.EagerGetContext(ctx, s, &o.Coupons)
+	})
 }
 
 // GetOrderCount will return an OrderCount struct containing statuses and counts
 func (s *Client) GetOrderCount() (*OrderCount, error) {
+	return s.GetOrderCountContext(context.Background())
+}
+
+// GetOrderCountContext - same as GetOrderCount but the request is cancelled when ctx is done
+func (s *Client) GetOrderCountContext(ctx context.Context) (*OrderCount, error) {
 	var data OrderCount
-	err := s.GetAndUnmarshal("v2/orders/count", &data)
+	err := s.GetAndUnmarshalContext(ctx, "v2/orders/count", &data)
 	if err != nil {
 		return nil, err
 	}
@@ -88,9 +146,14 @@ func (s *Client) GetOrderCount() (*OrderCount, error) {
 
 // GetShipment will return a slice of Shipment structs containing the shipment information
 func (s *Client) GetShipment(orderID int) (*[]Shipment, error) {
+	return s.GetShipmentContext(context.Background(), orderID)
+}
+
+// GetShipmentContext - same as GetShipment but the request is cancelled when ctx is done
+func (s *Client) GetShipmentContext(ctx context.Context, orderID int) (*[]Shipment, error) {
 	url := fmt.Sprintf("v2/orders/%d/shipments", orderID)
 	var data []Shipment
-	err := s.GetAndUnmarshal(url, &data)
+	err := s.GetAndUnmarshalContext(ctx, url, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -99,66 +162,146 @@ func (s *Client) GetShipment(orderID int) (*[]Shipment, error) {
 
 // GetShipmentsForOrders - Will attempt to concurrently fill the order slice elements with their respective shipment objects from the BC api
 func (s *Client) GetShipmentsForOrders(os []Order) (err error) {
-	var eg errgroup.Group
-	sem := make(chan bool, 20)
-	for i := range os {
-		j := i
-		eg.Go(func() error {
-			sem <- true
-			defer func() { <-sem }()
-			shipments, err := s.GetShipment(int(os[j].ID))
-			if err != nil {
-				return err
-			}
-			os[j].Shipments = *shipments
-			return nil
-		})
-	}
-	err = eg.Wait()
-	return
+	return s.GetShipmentsForOrdersContext(context.Background(), os)
+}
+
+// GetShipmentsForOrdersContext - same as GetShipmentsForOrders, cancelling ctx stops any outstanding requests
+func (s *Client) GetShipmentsForOrdersContext(ctx context.Context, os []Order) (err error) {
+	ctx, span := s.startBatch(ctx, "GetShipmentsForOrders", len(os))
+	defer func() { connect.EndSpan(span, err) }()
+	return s.forEachOrder(ctx, os, func(ctx context.Context, o *Order) error {
+		shipments, err := s.GetShipmentContext(ctx, int(o.ID))
+		if err != nil {
+			return err
+		}
+		o.Shipments = *shipments
+		return nil
+	})
 }
 
 // GetShipments will get shipments from the orders returned by the query
 func (s *Client) GetShipments(oq Query) ([]Shipment, error) {
-	os, err := s.GetOrderQuery(oq)
+	return s.GetShipmentsContext(context.Background(), oq)
+}
+
+// GetShipmentsContext - same as GetShipments, cancelling ctx stops paging and any outstanding shipment requests
+func (s *Client) GetShipmentsContext(ctx context.Context, oq Query) (_ []Shipment, err error) {
+	ctx, span := s.startBatch(ctx, "GetShipments", 0)
+	defer func() { connect.EndSpan(span, err) }()
+	os, err := s.GetOrderQueryContext(ctx, oq)
 	if err != nil {
 		return nil, err
 	}
-	var eg errgroup.Group
-	sem := make(chan bool, 20)
-	shipChan := make(chan Shipment, 3)
-	for _, o := range *os {
-		o := o
-		eg.Go(func() error {
-			sem <- true
-			defer func() { <-sem }()
-			var data []Shipment
-			url := fmt.Sprintf("v2/orders/%d/shipments", o.ID)
-			err := s.GetAndUnmarshal(url, &data)
-			if err != nil {
-				return err
-			}
-
-			for _, ship := range data {
-				shipChan <- ship
-			}
-			return nil
-		})
-	}
-
+	batchOrders(span, len(*os))
+	var mu sync.Mutex
 	shipments := make([]Shipment, 0)
-	go func() {
-		for i := range shipChan {
-			shipments = append(shipments, i)
+	err = s.forEachOrder(ctx, *os, func(ctx context.Context, o *Order) error {
+		data, err := s.GetShipmentContext(ctx, int(o.ID))
+		if err != nil {
+			return err
 		}
-	}()
-	err = eg.Wait()
+
+		mu.Lock()
+		shipments = append(shipments, *data...)
+		mu.Unlock()
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 	return shipments, nil
 }
 
+// GetOrderProducts will return the products of a single order, every page of them
+func (s *Client) GetOrderProducts(orderID int) ([]OrderProduct, error) {
+	return s.GetOrderProductsContext(context.Background(), orderID)
+}
+
+// GetOrderProductsContext - same as GetOrderProducts, cancelling ctx stops fetching any further pages
+func (s *Client) GetOrderProductsContext(ctx context.Context, orderID int) ([]OrderProduct, error) {
+	limit := s.Limit
+	if limit <= 0 {
+		// the page size BigCommerce uses when none is asked for
+		limit = 50
+	}
+	url := fmt.Sprintf("v2/orders/%d/products", orderID)
+	data := make([]OrderProduct, 0)
+	for page := 1; ; page++ {
+		var products []OrderProduct
+		err := s.GetAndUnmarshalWithQueryContext(ctx, url, fmt.Sprintf("page=%d&limit=%d", page, limit), &products)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, products...)
+		if len(products) < limit {
+			return data, nil
+		}
+	}
+}
+
+// CreateShipment will create a shipment on the order. The items are checked against the order's products first so
+// nothing is shipped to the wrong address or shipped twice
+func (s *Client) CreateShipment(orderID int, sc ShipmentCreate) (*Shipment, error) {
+	return s.CreateShipmentContext(context.Background(), orderID, sc)
+}
+
+// CreateShipmentContext - same as CreateShipment but the requests are cancelled when ctx is done
+func (s *Client) CreateShipmentContext(ctx context.Context, orderID int, sc ShipmentCreate) (*Shipment, error) {
+	err := sc.Validate()
+	if err != nil {
+		return nil, err
+	}
+	products, err := s.GetOrderProductsContext(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	err = sc.validateAgainst(products)
+	if err != nil {
+		return nil, err
+	}
+	var data Shipment
+	err = s.PostAndUnmarshalContext(ctx, fmt.Sprintf("v2/orders/%d/shipments", orderID), sc, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateShipment will apply the fields set in su to the shipment, returning the updated shipment
+func (s *Client) UpdateShipment(orderID int, shipmentID int, su ShipmentUpdate) (*Shipment, error) {
+	return s.UpdateShipmentContext(context.Background(), orderID, shipmentID, su)
+}
+
+// UpdateShipmentContext - same as UpdateShipment but the request is cancelled when ctx is done
+func (s *Client) UpdateShipmentContext(ctx context.Context, orderID int, shipmentID int, su ShipmentUpdate) (*Shipment, error) {
+	var data Shipment
+	err := s.PutAndUnmarshalContext(ctx, fmt.Sprintf("v2/orders/%d/shipments/%d", orderID, shipmentID), su, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteShipment will delete a single shipment from the order
+func (s *Client) DeleteShipment(orderID int, shipmentID int) error {
+	return s.DeleteShipmentContext(context.Background(), orderID, shipmentID)
+}
+
+// DeleteShipmentContext - same as DeleteShipment but the request is cancelled when ctx is done
+func (s *Client) DeleteShipmentContext(ctx context.Context, orderID int, shipmentID int) error {
+	return s.DeleteContext(ctx, fmt.Sprintf("v2/orders/%d/shipments/%d", orderID, shipmentID))
+}
+
+// DeleteAllShipments will delete every shipment of the order
+func (s *Client) DeleteAllShipments(orderID int) error {
+	return s.DeleteAllShipmentsContext(context.Background(), orderID)
+}
+
+// DeleteAllShipmentsContext - same as DeleteAllShipments but the request is cancelled when ctx is done
+func (s *Client) DeleteAllShipmentsContext(ctx context.Context, orderID int) error {
+	return s.DeleteContext(ctx, fmt.Sprintf("v2/orders/%d/shipments", orderID))
+}
+
 // GetRawQuery gets the struct in query string form
 func (q Query) GetRawQuery() (raw string, err error) {
 	if !q.MinDateCreated.IsZero() {
@@ -186,8 +329,13 @@ func (q Query) GetRawQuery() (raw string, err error) {
 
 // GetOrderQuery will return an ordered by ID slice of Order structs based on passed in query object
 func (s *Client) GetOrderFromRawQuery(rawQuery string) (*[]Order, error) {
+	return s.GetOrderFromRawQueryContext(context.Background(), rawQuery)
+}
+
+// GetOrderFromRawQueryContext - same as GetOrderFromRawQuery but the request is cancelled when ctx is done
+func (s *Client) GetOrderFromRawQueryContext(ctx context.Context, rawQuery string) (*[]Order, error) {
 	var data []Order
-	err := s.GetAndUnmarshalWithQuery("v2/orders/", rawQuery, &data)
+	err := s.GetAndUnmarshalWithQueryContext(ctx, "v2/orders/", rawQuery, &data)
 	if err != nil {
 		return nil, err
 	}
@@ -196,31 +344,21 @@ func (s *Client) GetOrderFromRawQuery(rawQuery string) (*[]Order, error) {
 
 // GetOrderQuery will return an ordered by ID slice of Order structs based on passed in query object
 func (s *Client) GetOrderQuery(oq Query) (*[]Order, error) {
-	if oq.Limit == 0 {
-		oq.Limit = s.Limit
-	}
+	return s.GetOrderQueryContext(context.Background(), oq)
+}
+
+// GetOrderQueryContext - same as GetOrderQuery, cancelling ctx stops fetching any further pages
+//
+// Every page is held in memory before sorting, use IterateOrders to work through large result sets
+func (s *Client) GetOrderQueryContext(ctx context.Context, oq Query) (*[]Order, error) {
+	it := s.IterateOrders(oq)
+	defer it.Close()
 	var allOrders []Order
-	orderCount := s.Limit + 1
-	getAllPages := true
-	page := 0
-
-	for getAllPages && orderCount >= s.Limit {
-		if page == 0 && oq.Page != 0 {
-			getAllPages = false
-		} else {
-			page = page + 1
-			oq.Page = page
-		}
-		rawQuery, err := oq.GetRawQuery()
-		if err != nil {
-			return nil, err
-		}
-		data, err := s.GetOrderFromRawQuery(rawQuery)
-		if err != nil {
-			return nil, err
-		}
-		orderCount = len(*data)
-		allOrders = append(allOrders, *data...)
+	for it.Next(ctx) {
+		allOrders = append(allOrders, it.Order())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
 	}
 	sort.Slice(allOrders, func(i, j int) bool {
 		return allOrders[i].ID < allOrders[j].ID
@@ -231,68 +369,65 @@ func (s *Client) GetOrderQuery(oq Query) (*[]Order, error) {
 
 // GetHydratedOrders Return a slice of Order structs based on passed in query object
 func (s *Client) GetHydratedOrders(oq Query) (*[]Order, error) {
-	orders, err := s.GetOrderQuery(oq)
-	if err != nil {
-		return nil, err
-	}
-	// hydrate products
-	err = s.GetProductDetail(*orders)
-	if err != nil {
-		return nil, err
-	}
-	// hydrate addresses
-	err = s.GetShippingAddressesForOrders(*orders)
-	if err != nil {
-		return nil, err
-	}
-	// hydrate coupons
-	err = s.GetCouponsForOrders(*orders)
-	if err != nil {
-		return nil, err
-	}
-	err = s.GetShipmentsForOrders(*orders)
-	if err != nil {
-		return nil, err
-	}
+	return s.GetHydratedOrdersContext(context.Background(), oq)
+}
 
-	return orders, nil
+// GetHydratedOrdersContext - same as GetHydratedOrders, cancelling ctx stops paging and any outstanding hydration requests
+func (s *Client) GetHydratedOrdersContext(ctx context.Context, oq Query) (*[]Order, error) {
+	orders, _, err := s.GetHydratedOrdersWithOptionsContext(ctx, oq, HydrateOptions{})
+	return orders, err
 }
 
 // GetOrders will return a slice of Order structs based on passed in status
 func (s *Client) GetOrders(status int) (*[]Order, error) {
-	return s.GetOrderQuery(Query{StatusID: status})
+	return s.GetOrdersContext(context.Background(), status)
+}
+
+// GetOrdersContext - same as GetOrders, cancelling ctx stops fetching any further pages
+func (s *Client) GetOrdersContext(ctx context.Context, status int) (*[]Order, error) {
+	return s.GetOrderQueryContext(ctx, Query{StatusID: status})
 }
 
 // GetOrdersAndProducts will return a slice of Order structs with their products based on passed in status
 func (s *Client) GetOrdersAndProducts(status int) (*[]Order, error) {
-	orders, err := s.GetOrders(status)
+	return s.GetOrdersAndProductsContext(context.Background(), status)
+}
+
+// GetOrdersAndProductsContext - same as GetOrdersAndProducts, cancelling ctx stops any outstanding requests
+func (s *Client) GetOrdersAndProductsContext(ctx context.Context, status int) (*[]Order, error) {
+	orders, err := s.GetOrdersContext(ctx, status)
 	if err != nil {
 		return nil, err
 	}
-	err = s.GetProductDetail(*orders)
+	err = s.GetProductDetailContext(ctx, *orders)
 
 	return orders, err
 }
 
 // GetHydratedOrderByID - return a single order with Products, Shipping Addresses, and Coupons Populated.
 func (s *Client) GetHydratedOrderByID(orderID string) (order Order, err error) {
-	err = s.GetAndUnmarshalWithQuery("v2/orders/", orderID, &order)
+	return s.GetHydratedOrderByIDContext(context.Background(), orderID)
+}
+
+// GetHydratedOrderByIDContext - same as GetHydratedOrderByID but the requests are cancelled when ctx is done
+func (s *Client) GetHydratedOrderByIDContext(ctx context.Context, orderID string) (order Order, err error) {
+	err = s.GetAndUnmarshalContext(ctx, "v2/orders/"+orderID, &order)
 	if err != nil {
 		return
 	}
-	err = order.ShippingResource.EagerGet(s, &order.ShippingAddresses)
+	err = order.ShippingResource.EagerGetContext(ctx, s, &order.ShippingAddresses)
 	if err != nil {
 		return
 	}
-	err = order.CouponResource.EagerGet(s, &order.Coupons)
+	err = order.CouponResource.EagerGetContext(ctx, s, &order.Coupons)
 	if err != nil {
 		return
 	}
-	err = order.ProductResource.EagerGet(s, &order.Products)
+	order.Products, err = s.GetOrderProductsContext(ctx, int(order.ID))
 	if err != nil {
 		return
 	}
-	shipments, err := s.GetShipment(int(order.ID))
+	shipments, err := s.GetShipmentContext(ctx, int(order.ID))
 	if err != nil {
 		return
 	}
@@ -300,9 +435,74 @@ func (s *Client) GetHydratedOrderByID(orderID string) (order Order, err error) {
 	return
 }
 
+// CreateOrder will validate and create a new order, returning the order as BigCommerce saved it
+func (s *Client) CreateOrder(o OrderCreate) (*Order, error) {
+	return s.CreateOrderContext(context.Background(), o)
+}
+
+// CreateOrderContext - same as CreateOrder but the request is cancelled when ctx is done
+func (s *Client) CreateOrderContext(ctx context.Context, o OrderCreate) (*Order, error) {
+	err := o.Validate()
+	if err != nil {
+		return nil, err
+	}
+	var data Order
+	err = s.PostAndUnmarshalContext(ctx, "v2/orders", o, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateOrder will validate and apply the fields set in patch to the order, returning the updated order
+func (s *Client) UpdateOrder(orderID int, patch OrderUpdate) (*Order, error) {
+	return s.UpdateOrderContext(context.Background(), orderID, patch)
+}
+
+// UpdateOrderContext - same as UpdateOrder but the request is cancelled when ctx is done
+func (s *Client) UpdateOrderContext(ctx context.Context, orderID int, patch OrderUpdate) (*Order, error) {
+	err := patch.Validate()
+	if err != nil {
+		return nil, err
+	}
+	var data Order
+	err = s.PutAndUnmarshalContext(ctx, fmt.Sprintf("v2/orders/%d", orderID), patch, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// ArchiveOrder will archive the order, BigCommerce keeps it around with IsDeleted set and it can be restored from the
+// control panel
+func (s *Client) ArchiveOrder(orderID int) error {
+	return s.ArchiveOrderContext(context.Background(), orderID)
+}
+
+// ArchiveOrderContext - same as ArchiveOrder but the request is cancelled when ctx is done
+func (s *Client) ArchiveOrderContext(ctx context.Context, orderID int) error {
+	return s.DeleteContext(ctx, fmt.Sprintf("v2/orders/%d", orderID))
+}
+
+// DeleteAllOrders will archive every order in the store, this is meant for cleaning out sandbox stores
+func (s *Client) DeleteAllOrders() error {
+	return s.DeleteAllOrdersContext(context.Background())
+}
+
+// DeleteAllOrdersContext - same as DeleteAllOrders but the request is cancelled when ctx is done
+func (s *Client) DeleteAllOrdersContext(ctx context.Context) error {
+	return s.DeleteContext(ctx, "v2/orders")
+}
+
 // GetAvailableStatuses will return a sorted slice of order statuses from the BC API
 func (s *Client) GetAvailableStatuses() (statuses Statuses, err error) {
-	err = s.GetAndUnmarshal(
+	return s.GetAvailableStatusesContext(context.Background())
+}
+
+// GetAvailableStatusesContext - same as GetAvailableStatuses but the request is cancelled when ctx is done
+func (s *Client) GetAvailableStatusesContext(ctx context.Context) (statuses Statuses, err error) {
+	err = s.GetAndUnmarshalContext(
+		ctx,
 		"v2/order_statuses",
 		&statuses,
 	)
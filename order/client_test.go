@@ -0,0 +1,218 @@
+package order_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dan-collins/biggommerce/bctest"
+	"github.com/dan-collins/biggommerce/connect"
+	"github.com/dan-collins/biggommerce/order"
+)
+
+// countRequests counts the requests the server received whose method and path start with prefix
+func countRequests(srv *bctest.Server, prefix string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if strings.HasPrefix(r, prefix) {
+			n++
+		}
+	}
+	return n
+}
+
+func manyProducts(n int) []order.OrderProduct {
+	products := make([]order.OrderProduct, n)
+	for i := range products {
+		products[i] = order.OrderProduct{ID: int64(i + 1), Name: fmt.Sprintf("Product %d", i+1), Quantity: 1}
+	}
+	return products
+}
+
+func TestGetOrderProductsPages(t *testing.T) {
+	srv := bctest.NewServer()
+	defer srv.Close()
+	id := srv.AddOrder(order.Order{StatusID: 11, Products: manyProducts(120)})
+	c := srv.Client()
+	c.Limit = 50
+
+	products, err := c.GetOrderProducts(int(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 120 {
+		t.Fatalf("got %d products, want 120", len(products))
+	}
+	if products[119].ID != 120 {
+		t.Errorf("last product = %d, want 120", products[119].ID)
+	}
+	path := fmt.Sprintf("GET /stores/%s/v2/orders/%d/products", bctest.DefaultStoreKey, id)
+	if n := countRequests(srv, path); n != 3 {
+		t.Errorf("fetched %d pages of products, want 3", n)
+	}
+}
+
+func TestGetOrderQueryPages(t *testing.T) {
+	srv := bctest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 120; i++ {
+		srv.AddOrder(order.Order{StatusID: 11})
+	}
+	for i := 0; i < 5; i++ {
+		srv.AddOrder(order.Order{StatusID: 10})
+	}
+	c := srv.Client()
+	c.Limit = 50
+
+	orders, err := c.GetOrderQuery(order.Query{StatusID: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*orders) != 120 {
+		t.Fatalf("got %d orders, want 120", len(*orders))
+	}
+	seen := map[int64]bool{}
+	for _, o := range *orders {
+		if o.StatusID != 11 || seen[o.ID] {
+			t.Fatalf("order %d status %d was unexpected or returned twice", o.ID, o.StatusID)
+		}
+		seen[o.ID] = true
+	}
+	path := fmt.Sprintf("GET /stores/%s/v2/orders/?", bctest.DefaultStoreKey)
+	if n := countRequests(srv, path); n != 3 {
+		t.Errorf("fetched %d pages of orders, want 3", n)
+	}
+
+	page, err := c.GetOrderQuery(order.Query{StatusID: 11, Page: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*page) != 20 {
+		t.Errorf("page 3 has %d orders, want 20", len(*page))
+	}
+}
+
+func TestGetHydratedOrderByIDNotFound(t *testing.T) {
+	srv := bctest.NewServer()
+	defer srv.Close()
+
+	_, err := srv.Client().GetHydratedOrderByID("999")
+	var apiErr *connect.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want an *connect.APIError", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || len(apiErr.Messages) == 0 {
+		t.Errorf("got status %d with messages %v, want a 404 with the V2 error array", apiErr.StatusCode, apiErr.Messages)
+	}
+}
+
+// addHydratableOrder stores an order with one of every sub resource
+func addHydratableOrder(srv *bctest.Server) int64 {
+	return srv.AddOrder(order.Order{
+		StatusID:          11,
+		Products:          []order.OrderProduct{{ID: 1, Name: "Mug", Quantity: 2}},
+		ShippingAddresses: []order.ShippingAddress{{ID: 1, Address: order.Address{FirstName: "Ada", Zip: "90210"}}},
+		Coupons:           []order.Coupon{{ID: 1, Code: "SAVE10"}},
+		Shipments:         []order.Shipment{{ID: 1, TrackingNumber: "1Z999"}},
+	})
+}
+
+func TestGetHydratedOrderByID(t *testing.T) {
+	srv := bctest.NewServer()
+	defer srv.Close()
+	id := addHydratableOrder(srv)
+
+	o, err := srv.Client().GetHydratedOrderByID(fmt.Sprint(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.ID != id || len(o.Products) != 1 || len(o.ShippingAddresses) != 1 || len(o.Coupons) != 1 || len(o.Shipments) != 1 {
+		t.Errorf("order %d was not fully hydrated: %+v", o.ID, o)
+	}
+	if o.ShippingAddresses[0].ID != 1 || o.Coupons[0].Code != "SAVE10" || o.Shipments[0].TrackingNumber != "1Z999" {
+		t.Errorf("sub resources came back wrong: %+v %+v %+v", o.ShippingAddresses, o.Coupons, o.Shipments)
+	}
+}
+
+func TestGetHydratedOrders(t *testing.T) {
+	srv := bctest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 30; i++ {
+		addHydratableOrder(srv)
+	}
+	srv.AddOrder(order.Order{StatusID: 10})
+	// throttled requests are retried rather than failing the batch
+	srv.InjectFault(bctest.Fault{Path: "/coupons", Status: http.StatusTooManyRequests, Times: 3})
+
+	orders, err := srv.Client().GetHydratedOrders(order.Query{StatusID: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*orders) != 30 {
+		t.Fatalf("got %d orders, want 30", len(*orders))
+	}
+	for _, o := range *orders {
+		if len(o.Products) != 1 || len(o.ShippingAddresses) != 1 || len(o.Coupons) != 1 || len(o.Shipments) != 1 {
+			t.Fatalf("order %d was not fully hydrated", o.ID)
+		}
+	}
+}
+
+func TestHydrationModes(t *testing.T) {
+	srv := bctest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 3; i++ {
+		addHydratableOrder(srv)
+	}
+	c := srv.Client()
+	c.Retry.MaxRetries = 0
+	srv.InjectFault(bctest.Fault{Path: "/coupons", Status: http.StatusInternalServerError})
+
+	_, _, err := c.GetHydratedOrdersWithMode(order.Query{StatusID: 11}, order.HydrateFailFast)
+	if err == nil {
+		t.Fatal("fail fast hydration should fail when coupons can not be loaded")
+	}
+
+	orders, errs, err := c.GetHydratedOrdersWithMode(order.Query{StatusID: 11}, order.HydrateBestEffort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs.Len() != 3 {
+		t.Fatalf("%d sub resources failed, want the coupons of 3 orders: %v", errs.Len(), errs.Err())
+	}
+	for _, o := range *orders {
+		if _, ok := errs[o.ID][order.SubResourceCoupons]; !ok || len(o.Products) != 1 || len(o.Coupons) != 0 {
+			t.Errorf("order %d should have everything but its coupons", o.ID)
+		}
+	}
+
+	srv.ClearFaults()
+	before := len(srv.Requests())
+	errs, err = c.RehydrateFailed(*orders, errs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs.Len() != 0 {
+		t.Fatalf("rehydrating still failed: %v", errs.Err())
+	}
+	for _, o := range *orders {
+		if len(o.Coupons) != 1 {
+			t.Errorf("order %d did not get its coupons on the second pass", o.ID)
+		}
+	}
+	if again := srv.Requests()[before:]; len(again) != 3 {
+		t.Errorf("rehydrating sent %v, want one request per failed sub resource", again)
+	}
+
+	onlyProducts, _, err := c.GetHydratedOrdersWithOptions(order.Query{StatusID: 11}, order.HydrateOptions{Include: order.IncludeProducts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, o := range *onlyProducts {
+		if len(o.Products) != 1 || o.Coupons != nil || o.Shipments != nil {
+			t.Errorf("order %d loaded more than its products", o.ID)
+		}
+	}
+}
@@ -1,14 +1,16 @@
 package order
 
+import "github.com/dan-collins/biggommerce/primative"
+
 // Coupon is a struct that represents the coupon detail objects that are part of the order
 type Coupon struct {
-	Amount   string  `json:"amount,omitempty"`
-	Code     string  `json:"code,omitempty"`
-	CouponID int64   `json:"coupon_id,omitempty"`
-	Discount float64 `json:"discount,string"`
-	ID       int64   `json:"id,omitempty"`
-	OrderID  int64   `json:"order_id,omitempty"`
-	Type     int64   `json:"type,omitempty"`
+	Amount   primative.Money `json:"amount"`
+	Code     string          `json:"code,omitempty"`
+	CouponID int64           `json:"coupon_id,omitempty"`
+	Discount primative.Money `json:"discount"`
+	ID       int64           `json:"id,omitempty"`
+	OrderID  int64           `json:"order_id,omitempty"`
+	Type     int64           `json:"type,omitempty"`
 }
 
 // CouponType will get you the text representation of the coupon type
@@ -0,0 +1,100 @@
+package order
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+// conversionPlaces is how many decimal places converted amounts keep, the same precision BigCommerce stores
+const conversionPlaces = 4
+
+var moneyType = reflect.TypeOf(primative.Money{})
+
+// exchangeRateFields are Money fields of Order that are rates rather than amounts, they are never converted
+var exchangeRateFields = map[string]bool{
+	"CurrencyExchangeRate":                    true,
+	"StoreDefaultToTransactionalExchangeRate": true,
+}
+
+// InStoreCurrency converts an amount of the order from the transactional currency (DefaultCurrencyCode), which is
+// what every monetary field of the order is in, to the store's default currency (StoreDefaultCurrencyCode)
+func (o Order) InStoreCurrency(amount primative.Money) (primative.Money, error) {
+	rate, err := o.storeToTransactionalRate()
+	if err != nil {
+		return primative.Money{}, err
+	}
+	return amount.Div(rate, conversionPlaces, primative.RoundHalfUp), nil
+}
+
+// InTransactionalCurrency converts an amount in the store's default currency to the transactional currency of the
+// order, the reverse of InStoreCurrency
+func (o Order) InTransactionalCurrency(storeAmount primative.Money) (primative.Money, error) {
+	rate, err := o.storeToTransactionalRate()
+	if err != nil {
+		return primative.Money{}, err
+	}
+	return storeAmount.Mul(rate).Round(conversionPlaces, primative.RoundHalfUp), nil
+}
+
+// InDisplayCurrency converts an amount of the order from the transactional currency to the currency the shopper saw
+// prices in on the storefront (CurrencyCode)
+func (o Order) InDisplayCurrency(amount primative.Money) (primative.Money, error) {
+	storeAmount, err := o.InStoreCurrency(amount)
+	if err != nil {
+		return primative.Money{}, err
+	}
+	if o.CurrencyExchangeRate.IsZero() {
+		return primative.Money{}, errors.New("order: order has no currency_exchange_rate")
+	}
+	return storeAmount.Mul(o.CurrencyExchangeRate).Round(conversionPlaces, primative.RoundHalfUp), nil
+}
+
+// ToStoreCurrency returns a copy of the order with every monetary field, including those of its Products, Coupons
+// and Shipments, converted to the store's default currency. Transactions are left in the currency they were made in
+func (o Order) ToStoreCurrency() (Order, error) {
+	rate, err := o.storeToTransactionalRate()
+	if err != nil {
+		return Order{}, err
+	}
+	converted := o
+	convertMoneyFields(reflect.ValueOf(&converted).Elem(), func(m primative.Money) primative.Money {
+		return m.Div(rate, conversionPlaces, primative.RoundHalfUp)
+	})
+	converted.DefaultCurrencyID = 0
+	converted.DefaultCurrencyCode = o.StoreDefaultCurrencyCode
+	converted.StoreDefaultToTransactionalExchangeRate = primative.NewMoney(1, 0)
+	return converted, nil
+}
+
+func (o Order) storeToTransactionalRate() (primative.Money, error) {
+	if o.StoreDefaultToTransactionalExchangeRate.IsZero() {
+		return primative.Money{}, errors.New("order: order has no store_default_to_transactional_exchange_rate")
+	}
+	return o.StoreDefaultToTransactionalExchangeRate, nil
+}
+
+// convertMoneyFields applies fn to every Money field of the struct v, following slices of structs so nested lines
+// are converted too. Slices are copied so the original order is left alone
+func convertMoneyFields(v reflect.Value, fn func(primative.Money) primative.Money) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		name := t.Field(i).Name
+		switch {
+		case name == "Transactions" || exchangeRateFields[name]:
+		case field.Type() == moneyType:
+			field.Set(reflect.ValueOf(fn(field.Interface().(primative.Money))))
+		case field.Kind() == reflect.Struct:
+			convertMoneyFields(field, fn)
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct && !field.IsNil():
+			copied := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+			reflect.Copy(copied, field)
+			for j := 0; j < copied.Len(); j++ {
+				convertMoneyFields(copied.Index(j), fn)
+			}
+			field.Set(copied)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package order
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+// walkMoney calls fn with the path and value of every Money field of the struct v, giving every nil slice of structs
+// one element first when fill is set so the fields of nested lines are reached too
+func walkMoney(v reflect.Value, path string, fill bool, fn func(path string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field, sf := v.Field(i), t.Field(i)
+		if sf.PkgPath != "" {
+			continue
+		}
+		name := path + sf.Name
+		switch {
+		case field.Type() == moneyType:
+			fn(name, field)
+		case field.Kind() == reflect.Struct:
+			walkMoney(field, name+".", fill, fn)
+		case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct:
+			if fill && field.Len() == 0 {
+				field.Set(reflect.MakeSlice(field.Type(), 1, 1))
+			}
+			for j := 0; j < field.Len(); j++ {
+				walkMoney(field.Index(j), name+"[].", fill, fn)
+			}
+		}
+	}
+}
+
+func TestToStoreCurrency(t *testing.T) {
+	var o Order
+	ten := primative.MustParseMoney("10.00")
+	walkMoney(reflect.ValueOf(&o).Elem(), "", true, func(path string, field reflect.Value) {
+		field.Set(reflect.ValueOf(ten))
+	})
+	o.DefaultCurrencyCode, o.StoreDefaultCurrencyCode = "EUR", "USD"
+	o.StoreDefaultToTransactionalExchangeRate = primative.MustParseMoney("0.8")
+	o.CurrencyExchangeRate = primative.MustParseMoney("1.25")
+
+	converted, err := o.ToStoreCurrency()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := 0
+	walkMoney(reflect.ValueOf(&converted).Elem(), "", false, func(path string, field reflect.Value) {
+		got := field.Interface().(primative.Money)
+		switch {
+		case path == "StoreDefaultToTransactionalExchangeRate":
+			if !got.Equal(primative.NewMoney(1, 0)) {
+				t.Errorf("%s = %s, want 1 now that the order is in the store's currency", path, got)
+			}
+		case path == "CurrencyExchangeRate":
+			if !got.Equal(o.CurrencyExchangeRate) {
+				t.Errorf("%s = %s, want the rate left alone", path, got)
+			}
+		case strings.HasPrefix(path, "Transactions[]."):
+			if !got.Equal(ten) {
+				t.Errorf("%s = %s, want it left alone", path, got)
+			}
+		default:
+			fields++
+			if !got.Equal(primative.MustParseMoney("12.5")) {
+				t.Errorf("%s = %s, want 10.00 EUR at 0.8 to be 12.50 USD", path, got)
+			}
+		}
+	})
+	if fields < 20 || len(converted.Products) != 1 || len(converted.Coupons) != 1 || len(converted.Shipments) != 1 {
+		t.Errorf("only %d amounts were checked, the nested lines should have been reached", fields)
+	}
+	if converted.DefaultCurrencyCode != "USD" {
+		t.Errorf("converted order is in %s, want USD", converted.DefaultCurrencyCode)
+	}
+	if !o.Products[0].PriceIncTax.Equal(ten) || !o.SubtotalIncTax.Equal(ten) {
+		t.Error("converting should leave the original order and its lines alone")
+	}
+
+	if _, err := (Order{}).ToStoreCurrency(); err == nil {
+		t.Error("an order without an exchange rate can not be converted")
+	}
+}
+
+func TestOrderCurrencyConversion(t *testing.T) {
+	o := Order{
+		StoreDefaultToTransactionalExchangeRate: primative.MustParseMoney("3"),
+		CurrencyExchangeRate:                    primative.MustParseMoney("1.5"),
+	}
+	m := primative.MustParseMoney
+
+	store, err := o.InStoreCurrency(m("10"))
+	if err != nil || store.String() != "3.3333" {
+		t.Errorf("InStoreCurrency(10) = %s, %v, want 3.3333", store, err)
+	}
+	store, _ = o.InStoreCurrency(m("0.00005"))
+	if store.String() != "0.0000" {
+		t.Errorf("InStoreCurrency(0.00005) = %s, want it rounded to 0.0000", store)
+	}
+	back, err := o.InTransactionalCurrency(m("3.3333"))
+	if err != nil || back.String() != "9.9999" {
+		t.Errorf("InTransactionalCurrency(3.3333) = %s, %v, want 9.9999", back, err)
+	}
+	display, err := o.InDisplayCurrency(m("10"))
+	if err != nil || display.String() != "5.0000" {
+		t.Errorf("InDisplayCurrency(10) = %s, %v, want 5.0000", display, err)
+	}
+
+	o.CurrencyExchangeRate = primative.Money{}
+	if _, err := o.InDisplayCurrency(m("10")); err == nil {
+		t.Error("InDisplayCurrency needs currency_exchange_rate")
+	}
+	if _, err := (Order{}).InStoreCurrency(m("10")); err == nil {
+		t.Error("InStoreCurrency needs store_default_to_transactional_exchange_rate")
+	}
+}
@@ -0,0 +1,266 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dan-collins/biggommerce/connect"
+)
+
+// SubResource is one of the lists GetHydratedOrders loads onto every order
+type SubResource string
+
+// Sub resources of an order
+const (
+	SubResourceProducts          SubResource = "products"
+	SubResourceShippingAddresses SubResource = "shipping_addresses"
+	SubResourceCoupons           SubResource = "coupons"
+	SubResourceShipments         SubResource = "shipments"
+)
+
+// subResources is the order the sub resources are hydrated in
+var subResources = []SubResource{
+	SubResourceProducts,
+	SubResourceShippingAddresses,
+	SubResourceCoupons,
+	SubResourceShipments,
+}
+
+// Include is a set of sub resources to hydrate, combine them with |
+type Include uint
+
+// Sub resources HydrateOptions can include
+const (
+	IncludeProducts Include = 1 << iota
+	IncludeShippingAddresses
+	IncludeCoupons
+	IncludeShipments
+	// IncludeAll is every sub resource, what GetHydratedOrders loads
+	IncludeAll = IncludeProducts | IncludeShippingAddresses | IncludeCoupons | IncludeShipments
+)
+
+// includes maps every sub resource to its Include flag
+var includes = map[SubResource]Include{
+	SubResourceProducts:          IncludeProducts,
+	SubResourceShippingAddresses: IncludeShippingAddresses,
+	SubResourceCoupons:           IncludeCoupons,
+	SubResourceShipments:         IncludeShipments,
+}
+
+// Has reports whether r is part of the set
+func (i Include) Has(r SubResource) bool {
+	return i&includes[r] != 0
+}
+
+// HydrationMode decides what hydrating does when a sub resource of one order fails to load
+type HydrationMode int
+
+const (
+	// HydrateFailFast gives up on the whole batch at the first failure, the way GetHydratedOrders always has
+	HydrateFailFast HydrationMode = iota
+	// HydrateBestEffort loads everything it can and reports what failed in HydrationErrors
+	HydrateBestEffort
+	// HydrateRetryFailed is HydrateBestEffort followed by one more pass over only the sub resources that failed
+	HydrateRetryFailed
+)
+
+// HydrationErrors are the sub resources that failed to load while hydrating, keyed by order id. An order in the map
+// has its other sub resources loaded, the failed ones are left empty
+type HydrationErrors map[int64]map[SubResource]error
+
+// HydrateOptions picks what is loaded onto each order and how
+type HydrateOptions struct {
+	// Include is the sub resources to load, all of them when 0
+	Include Include
+	// Mode decides what happens when a sub resource fails to load, HydrateFailFast by default
+	Mode HydrationMode
+	// Concurrency is how many orders are hydrated at once, the client's Concurrency when 0
+	Concurrency int
+}
+
+func (o HydrateOptions) include() Include {
+	if o.Include == 0 {
+		return IncludeAll
+	}
+	return o.Include
+}
+
+// Len is how many sub resources failed across all orders
+func (h HydrationErrors) Len() int {
+	n := 0
+	for _, failed := range h {
+		n += len(failed)
+	}
+	return n
+}
+
+// Err joins every failure into a single error, nil when nothing failed
+func (h HydrationErrors) Err() error {
+	if len(h) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(h))
+	for id := range h {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	var errs []error
+	for _, id := range ids {
+		for _, r := range subResources {
+			if err, ok := h[id][r]; ok {
+				errs = append(errs, fmt.Errorf("order %d %s: %w", id, r, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h HydrationErrors) add(orderID int64, r SubResource, err error) {
+	if h[orderID] == nil {
+		h[orderID] = map[SubResource]error{}
+	}
+	h[orderID][r] = err
+}
+
+// GetHydratedOrdersWithOptions is GetHydratedOrders loading only the sub resources opts includes. Every order is
+// hydrated in a single pass, its sub resources fetched one after the other while opts.Concurrency orders are worked
+// on at once, so the slowest order rather than the slowest request of each sub resource bounds how long it takes.
+//
+// With HydrateFailFast the first failure is returned as the error, otherwise every order comes back along with the
+// HydrationErrors of the ones that could not be fully hydrated. The error is only set when the orders themselves
+// could not be fetched, the batch failed fast, or ctx is done
+func (s *Client) GetHydratedOrdersWithOptions(oq Query, opts HydrateOptions) (*[]Order, HydrationErrors, error) {
+	return s.GetHydratedOrdersWithOptionsContext(context.Background(), oq, opts)
+}
+
+// GetHydratedOrdersWithOptionsContext - same as GetHydratedOrdersWithOptions, cancelling ctx stops paging and any
+// outstanding hydration requests
+func (s *Client) GetHydratedOrdersWithOptionsContext(ctx context.Context, oq Query, opts HydrateOptions) (_ *[]Order, _ HydrationErrors, err error) {
+	ctx, span := s.startBatch(ctx, "GetHydratedOrders", 0)
+	defer func() { connect.EndSpan(span, err) }()
+	orders, err := s.GetOrderQueryContext(ctx, oq)
+	if err != nil {
+		return nil, nil, err
+	}
+	batchOrders(span, len(*orders))
+	errs, err := s.HydrateOrdersContext(ctx, *orders, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return orders, errs, nil
+}
+
+// GetHydratedOrdersWithMode is GetHydratedOrders with a choice of what happens when a sub resource fails to load, see
+// GetHydratedOrdersWithOptions
+func (s *Client) GetHydratedOrdersWithMode(oq Query, mode HydrationMode) (*[]Order, HydrationErrors, error) {
+	return s.GetHydratedOrdersWithModeContext(context.Background(), oq, mode)
+}
+
+// GetHydratedOrdersWithModeContext - same as GetHydratedOrdersWithMode, cancelling ctx stops paging and any
+// outstanding hydration requests
+func (s *Client) GetHydratedOrdersWithModeContext(ctx context.Context, oq Query, mode HydrationMode) (*[]Order, HydrationErrors, error) {
+	return s.GetHydratedOrdersWithOptionsContext(ctx, oq, HydrateOptions{Mode: mode})
+}
+
+// HydrateOrders loads the sub resources opts includes onto orders that were already fetched, e.g. by an
+// OrderIterator, the same way GetHydratedOrdersWithOptions does
+func (s *Client) HydrateOrders(os []Order, opts HydrateOptions) (HydrationErrors, error) {
+	return s.HydrateOrdersContext(context.Background(), os, opts)
+}
+
+// HydrateOrdersContext - same as HydrateOrders, cancelling ctx stops any outstanding requests
+func (s *Client) HydrateOrdersContext(ctx context.Context, os []Order, opts HydrateOptions) (_ HydrationErrors, err error) {
+	ctx, span := s.startBatch(ctx, "HydrateOrders", len(os))
+	defer func() { connect.EndSpan(span, err) }()
+	include := opts.include()
+	errs, err := s.hydrate(ctx, os, opts.Concurrency, opts.Mode, func(o *Order, r SubResource) bool {
+		return include.Has(r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if opts.Mode == HydrateRetryFailed && len(errs) > 0 {
+		return s.rehydrate(ctx, os, opts.Concurrency, errs)
+	}
+	return errs, nil
+}
+
+// RehydrateFailed loads again only the sub resources errs says failed on os, e.g. a while after
+// GetHydratedOrdersWithOptions ran into an outage. It returns what still failed, errs itself is left as it is
+func (s *Client) RehydrateFailed(os []Order, errs HydrationErrors) (HydrationErrors, error) {
+	return s.RehydrateFailedContext(context.Background(), os, errs)
+}
+
+// RehydrateFailedContext - same as RehydrateFailed, cancelling ctx stops any outstanding requests
+func (s *Client) RehydrateFailedContext(ctx context.Context, os []Order, errs HydrationErrors) (HydrationErrors, error) {
+	return s.rehydrate(ctx, os, s.Concurrency, errs)
+}
+
+func (s *Client) rehydrate(ctx context.Context, os []Order, concurrency int, errs HydrationErrors) (_ HydrationErrors, err error) {
+	ctx, span := s.startBatch(ctx, "RehydrateFailed", len(errs))
+	defer func() { connect.EndSpan(span, err) }()
+	return s.hydrate(ctx, os, concurrency, HydrateBestEffort, func(o *Order, r SubResource) bool {
+		_, failed := errs[o.ID][r]
+		return failed
+	})
+}
+
+// hydrate runs a single pass over os, loading every sub resource of an order wanted says it should have. Failures
+// are collected unless mode is HydrateFailFast
+func (s *Client) hydrate(ctx context.Context, os []Order, concurrency int, mode HydrationMode, wanted func(o *Order, r SubResource) bool) (HydrationErrors, error) {
+	var mu sync.Mutex
+	errs := HydrationErrors{}
+	err := s.forEachOrderN(ctx, os, concurrency, func(ctx context.Context, o *Order) error {
+		for _, r := range subResources {
+			if !wanted(o, r) {
+				continue
+			}
+			loadErr := s.loadSubResource(ctx, o, r)
+			if loadErr == nil {
+				continue
+			}
+			if mode == HydrateFailFast {
+				return fmt.Errorf("order %d %s: %w", o.ID, r, loadErr)
+			}
+			if ctx.Err() != nil {
+				return loadErr
+			}
+			mu.Lock()
+			errs.add(o.ID, r, loadErr)
+			mu.Unlock()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// loadSubResource fetches one sub resource of o and sets it on o
+func (s *Client) loadSubResource(ctx context.Context, o *Order, r SubResource) error {
+	switch r {
+	case SubResourceProducts:
+		products, err := s.GetOrderProductsContext(ctx, int(o.ID))
+		if err != nil {
+			return err
+		}
+		o.Products = products
+		return nil
+	case SubResourceShippingAddresses:
+		return o.ShippingResource.EagerGetContext(ctx, s, &o.ShippingAddresses)
+	case SubResourceCoupons:
+		return o.CouponResource.EagerGetContext(ctx, s, &o.Coupons)
+	case SubResourceShipments:
+		shipments, err := s.GetShipmentContext(ctx, int(o.ID))
+		if err != nil {
+			return err
+		}
+		o.Shipments = *shipments
+		return nil
+	}
+	return fmt.Errorf("order: unknown sub resource %q", r)
+}
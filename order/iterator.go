@@ -0,0 +1,151 @@
+package order
+
+import "context"
+
+// OrderIterator walks the orders matching a Query one page at a time, so only a single page (two with Prefetch) is
+// ever held in memory. Orders come back in the order the API returns them, use Query.Sort to control it.
+//
+//	it := client.IterateOrders(order.Query{StatusID: 11})
+//	defer it.Close()
+//	for it.Next(ctx) {
+//		o := it.Order()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type OrderIterator struct {
+	// Prefetch requests the next page in the background while the current one is being read, set it before the
+	// first call to Next
+	Prefetch bool
+
+	client *Client
+	query  Query
+	// single is set when the query asked for one specific page
+	single bool
+	page   int
+
+	buf     []Order
+	current Order
+	err     error
+	done    bool
+
+	pending chan orderPage
+	cancel  context.CancelFunc
+}
+
+type orderPage struct {
+	orders []Order
+	err    error
+}
+
+// IterateOrders returns an iterator over the orders matching oq. If oq.Page is set only that page is read, otherwise
+// every page from the first on is read until a short page comes back
+func (s *Client) IterateOrders(oq Query) *OrderIterator {
+	if oq.Limit == 0 {
+		oq.Limit = s.Limit
+	}
+	return &OrderIterator{
+		client: s,
+		query:  oq,
+		single: oq.Page != 0,
+		page:   oq.Page,
+	}
+}
+
+// Next advances to the next order, fetching the next page when the current one is used up. It returns false when
+// there are no more orders or a request failed, check Err to tell them apart
+func (it *OrderIterator) Next(ctx context.Context) bool {
+	for len(it.buf) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		page := it.nextPage(ctx)
+		if page.err != nil {
+			it.err = page.err
+			return false
+		}
+		if it.single || len(page.orders) < it.query.Limit {
+			it.done = true
+		}
+		if len(page.orders) == 0 {
+			it.done = true
+		}
+		it.buf = page.orders
+		if it.Prefetch && !it.done {
+			it.startPrefetch(ctx)
+		}
+	}
+	it.current = it.buf[0]
+	it.buf = it.buf[1:]
+	return true
+}
+
+// Order returns the order Next moved to
+func (it *OrderIterator) Order() Order {
+	return it.current
+}
+
+// Err returns the error that stopped the iterator, if any
+func (it *OrderIterator) Err() error {
+	return it.err
+}
+
+// Close stops the iterator early and abandons any page being prefetched, it is safe to call more than once
+func (it *OrderIterator) Close() {
+	it.done = true
+	it.buf = nil
+	if it.cancel != nil {
+		it.cancel()
+		it.cancel = nil
+	}
+	it.pending = nil
+}
+
+// nextPage returns the prefetched page if there is one, otherwise it fetches the next page now
+func (it *OrderIterator) nextPage(ctx context.Context) orderPage {
+	if it.pending != nil {
+		pending := it.pending
+		it.pending = nil
+		select {
+		case page := <-pending:
+			return page
+		case <-ctx.Done():
+			return orderPage{err: ctx.Err()}
+		}
+	}
+	return it.fetch(ctx, it.advance())
+}
+
+// advance moves to the next page number and returns it
+func (it *OrderIterator) advance() int {
+	if !it.single {
+		it.page++
+	}
+	return it.page
+}
+
+func (it *OrderIterator) fetch(ctx context.Context, page int) orderPage {
+	oq := it.query
+	oq.Page = page
+	rawQuery, err := oq.GetRawQuery()
+	if err != nil {
+		return orderPage{err: err}
+	}
+	data, err := it.client.GetOrderFromRawQueryContext(ctx, rawQuery)
+	if err != nil {
+		return orderPage{err: err}
+	}
+	return orderPage{orders: *data}
+}
+
+func (it *OrderIterator) startPrefetch(ctx context.Context) {
+	if it.cancel != nil {
+		it.cancel()
+	}
+	ctx, it.cancel = context.WithCancel(ctx)
+	pending := make(chan orderPage, 1)
+	page := it.advance()
+	go func() {
+		pending <- it.fetch(ctx, page)
+	}()
+	it.pending = pending
+}
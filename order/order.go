@@ -15,48 +15,48 @@ type Order struct {
 	DateShipped                             primative.BCDate   `json:"date_shipped,omitempty"`
 	StatusID                                int64              `json:"status_id,omitempty"`
 	Status                                  string             `json:"status,omitempty"`
-	SubtotalExTax                           float64            `json:"subtotal_ex_tax,string"`
-	SubtotalIncTax                          float64            `json:"subtotal_inc_tax,string"`
-	SubtotalTax                             float64            `json:"subtotal_tax,string"`
-	BaseShippingCost                        float64            `json:"base_shipping_cost,string"`
-	ShippingCostExTax                       float64            `json:"shipping_cost_ex_tax,string"`
-	ShippingCostIncTax                      float64            `json:"shipping_cost_inc_tax,string"`
-	ShippingCostTax                         float64            `json:"shipping_cost_tax,string"`
+	SubtotalExTax                           primative.Money    `json:"subtotal_ex_tax"`
+	SubtotalIncTax                          primative.Money    `json:"subtotal_inc_tax"`
+	SubtotalTax                             primative.Money    `json:"subtotal_tax"`
+	BaseShippingCost                        primative.Money    `json:"base_shipping_cost"`
+	ShippingCostExTax                       primative.Money    `json:"shipping_cost_ex_tax"`
+	ShippingCostIncTax                      primative.Money    `json:"shipping_cost_inc_tax"`
+	ShippingCostTax                         primative.Money    `json:"shipping_cost_tax"`
 	ShippingCostTaxClassID                  int64              `json:"shipping_cost_tax_class_id,omitempty"`
-	BaseHandlingCost                        float64            `json:"base_handling_cost,string"`
-	HandlingCostExTax                       float64            `json:"handling_cost_ex_tax,string"`
-	HandlingCostIncTax                      float64            `json:"handling_cost_inc_tax,string"`
-	HandlingCostTax                         float64            `json:"handling_cost_tax,string"`
+	BaseHandlingCost                        primative.Money    `json:"base_handling_cost"`
+	HandlingCostExTax                       primative.Money    `json:"handling_cost_ex_tax"`
+	HandlingCostIncTax                      primative.Money    `json:"handling_cost_inc_tax"`
+	HandlingCostTax                         primative.Money    `json:"handling_cost_tax"`
 	HandlingCostTaxClassID                  int64              `json:"handling_cost_tax_class_id,omitempty"`
-	BaseWrappingCost                        float64            `json:"base_wrapping_cost,string"`
-	WrappingCostExTax                       float64            `json:"wrapping_cost_ex_tax,string"`
-	WrappingCostIncTax                      float64            `json:"wrapping_cost_inc_tax,string"`
-	WrappingCostTax                         float64            `json:"wrapping_cost_tax,string"`
+	BaseWrappingCost                        primative.Money    `json:"base_wrapping_cost"`
+	WrappingCostExTax                       primative.Money    `json:"wrapping_cost_ex_tax"`
+	WrappingCostIncTax                      primative.Money    `json:"wrapping_cost_inc_tax"`
+	WrappingCostTax                         primative.Money    `json:"wrapping_cost_tax"`
 	WrappingCostTaxClassID                  int64              `json:"wrapping_cost_tax_class_id,omitempty"`
-	TotalExTax                              float64            `json:"total_ex_tax,string"`
-	TotalIncTax                             float64            `json:"total_inc_tax,string"`
-	TotalTax                                float64            `json:"total_tax,string"`
+	TotalExTax                              primative.Money    `json:"total_ex_tax"`
+	TotalIncTax                             primative.Money    `json:"total_inc_tax"`
+	TotalTax                                primative.Money    `json:"total_tax"`
 	ItemsTotal                              int64              `json:"items_total,omitempty"`
 	ItemsShipped                            int64              `json:"items_shipped,omitempty"`
 	PaymentMethod                           string             `json:"payment_method,omitempty"`
 	PaymentProviderID                       string             `json:"payment_provider_id,omitempty"`
 	PaymentStatus                           string             `json:"payment_status,omitempty"`
-	RefundedAmount                          float64            `json:"refunded_amount,string"`
+	RefundedAmount                          primative.Money    `json:"refunded_amount"`
 	OrderIsDigital                          bool               `json:"order_is_digital,omitempty"`
-	StoreCreditAmount                       float64            `json:"store_credit_amount,string"`
-	GiftCertificateAmount                   float64            `json:"gift_certificate_amount,string"`
+	StoreCreditAmount                       primative.Money    `json:"store_credit_amount"`
+	GiftCertificateAmount                   primative.Money    `json:"gift_certificate_amount"`
 	IPAddress                               string             `json:"ip_address,omitempty"`
 	GeoipCountry                            string             `json:"geoip_country,omitempty"`
 	GeoipCountryIso2                        string             `json:"geoip_country_iso2,omitempty"`
 	CurrencyID                              int64              `json:"currency_id,omitempty"`
 	CurrencyCode                            string             `json:"currency_code,omitempty"`
-	CurrencyExchangeRate                    string             `json:"currency_exchange_rate,omitempty"`
+	CurrencyExchangeRate                    primative.Money    `json:"currency_exchange_rate"`
 	DefaultCurrencyID                       int64              `json:"default_currency_id,omitempty"`
 	DefaultCurrencyCode                     string             `json:"default_currency_code,omitempty"`
 	StaffNotes                              string             `json:"staff_notes,omitempty"`
 	CustomerMessage                         string             `json:"customer_message,omitempty"`
-	DiscountAmount                          float64            `json:"discount_amount,string"`
-	CouponDiscount                          float64            `json:"coupon_discount,string"`
+	DiscountAmount                          primative.Money    `json:"discount_amount"`
+	CouponDiscount                          primative.Money    `json:"coupon_discount"`
 	ShippingAddressCount                    int64              `json:"shipping_address_count,omitempty"`
 	IsDeleted                               bool               `json:"is_deleted,omitempty"`
 	EbayOrderID                             string             `json:"ebay_order_id,omitempty"`
@@ -74,12 +74,13 @@ type Order struct {
 	CouponResource                          primative.Resource `json:"coupons,omitempty"`
 	Coupons                                 []Coupon
 	Shipments                               []Shipment
-	ExternalID                              interface{} `json:"external_id"`
-	ExternalMerchantID                      interface{} `json:"external_merchant_id"`
-	TaxProviderID                           string      `json:"tax_provider_id,omitempty"`
-	StoreDefaultCurrencyCode                string      `json:"store_default_currency_code,omitempty"`
-	StoreDefaultToTransactionalExchangeRate string      `json:"store_default_to_transactional_exchange_rate,omitempty"`
-	CustomStatus                            string      `json:"custom_status,omitempty"`
+	Transactions                            []Transaction
+	ExternalID                              interface{}     `json:"external_id"`
+	ExternalMerchantID                      interface{}     `json:"external_merchant_id"`
+	TaxProviderID                           string          `json:"tax_provider_id,omitempty"`
+	StoreDefaultCurrencyCode                string          `json:"store_default_currency_code,omitempty"`
+	StoreDefaultToTransactionalExchangeRate primative.Money `json:"store_default_to_transactional_exchange_rate"`
+	CustomStatus                            string          `json:"custom_status,omitempty"`
 }
 
 // Query struct to handle orders endpoint search query params, if you want orders with a status of 0 ("incomplete" in BC)
@@ -1,12 +1,14 @@
 package order
 
+import "github.com/dan-collins/biggommerce/primative"
+
 // AppliedDiscount is a struct that represents the applied discounts included in the order product resource
 type AppliedDiscount struct {
-	ID     string      `json:"id,omitempty"`
-	Amount float64     `json:"amount,string,omitempty"`
-	Name   string      `json:"name,omitempty"`
-	Code   interface{} `json:"code"`
-	Target string      `json:"target,omitempty"`
+	ID     string          `json:"id,omitempty"`
+	Amount primative.Money `json:"amount"`
+	Name   string          `json:"name,omitempty"`
+	Code   interface{}     `json:"code"`
+	Target string          `json:"target,omitempty"`
 }
 
 // ProductOption is a struct that represents the product option detail objects that are part of the order product
@@ -28,37 +30,38 @@ type OrderProduct struct {
 	ID                   int64             `json:"id,omitempty"`
 	OrderID              int64             `json:"order_id,omitempty"`
 	ProductID            int64             `json:"product_id,omitempty"`
+	VariantID            int64             `json:"variant_id,omitempty"`
 	OrderAddressID       int64             `json:"order_address_id,omitempty"`
 	Name                 string            `json:"name,omitempty"`
 	Sku                  string            `json:"sku,omitempty"`
 	Upc                  string            `json:"upc,omitempty"`
 	Type                 string            `json:"type,omitempty"`
-	BasePrice            float64           `json:"base_price,string"`
-	PriceExTax           float64           `json:"price_ex_tax,string"`
-	PriceIncTax          float64           `json:"price_inc_tax,string"`
-	PriceTax             float64           `json:"price_tax,string"`
-	BaseTotal            float64           `json:"base_total,string"`
-	TotalExTax           float64           `json:"total_ex_tax,string"`
-	TotalIncTax          float64           `json:"total_inc_tax,string"`
-	TotalTax             float64           `json:"total_tax,string"`
+	BasePrice            primative.Money   `json:"base_price"`
+	PriceExTax           primative.Money   `json:"price_ex_tax"`
+	PriceIncTax          primative.Money   `json:"price_inc_tax"`
+	PriceTax             primative.Money   `json:"price_tax"`
+	BaseTotal            primative.Money   `json:"base_total"`
+	TotalExTax           primative.Money   `json:"total_ex_tax"`
+	TotalIncTax          primative.Money   `json:"total_inc_tax"`
+	TotalTax             primative.Money   `json:"total_tax"`
 	Weight               float64           `json:"weight,string"`
 	Quantity             int64             `json:"quantity,omitempty"`
-	BaseCostPrice        float64           `json:"base_cost_price,string"`
-	CostPriceIncTax      float64           `json:"cost_price_inc_tax,string"`
-	CostPriceExTax       float64           `json:"cost_price_ex_tax,string"`
-	CostPriceTax         float64           `json:"cost_price_tax,string"`
+	BaseCostPrice        primative.Money   `json:"base_cost_price"`
+	CostPriceIncTax      primative.Money   `json:"cost_price_inc_tax"`
+	CostPriceExTax       primative.Money   `json:"cost_price_ex_tax"`
+	CostPriceTax         primative.Money   `json:"cost_price_tax"`
 	IsRefunded           bool              `json:"is_refunded,omitempty"`
 	QuantityRefunded     int64             `json:"quantity_refunded,omitempty"`
-	RefundAmount         float64           `json:"refund_amount,string"`
+	RefundAmount         primative.Money   `json:"refund_amount"`
 	ReturnID             int64             `json:"return_id,omitempty"`
 	WrappingName         string            `json:"wrapping_name,omitempty"`
-	BaseWrappingCost     float64           `json:"base_wrapping_cost,string"`
-	WrappingCostExTax    float64           `json:"wrapping_cost_ex_tax,string"`
-	WrappingCostIncTax   float64           `json:"wrapping_cost_inc_tax,string"`
-	WrappingCostTax      float64           `json:"wrapping_cost_tax,string"`
+	BaseWrappingCost     primative.Money   `json:"base_wrapping_cost"`
+	WrappingCostExTax    primative.Money   `json:"wrapping_cost_ex_tax"`
+	WrappingCostIncTax   primative.Money   `json:"wrapping_cost_inc_tax"`
+	WrappingCostTax      primative.Money   `json:"wrapping_cost_tax"`
 	WrappingMessage      string            `json:"wrapping_message,omitempty"`
 	QuantityShipped      int64             `json:"quantity_shipped,omitempty"`
-	FixedShippingCost    float64           `json:"fixed_shipping_cost,string"`
+	FixedShippingCost    primative.Money   `json:"fixed_shipping_cost"`
 	EbayItemID           string            `json:"ebay_item_id,omitempty"`
 	EbayTransactionID    string            `json:"ebay_transaction_id,omitempty"`
 	OptionSetID          int64             `json:"option_set_id,omitempty"`
@@ -0,0 +1,220 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dan-collins/biggommerce/primative"
+	"github.com/google/go-querystring/query"
+)
+
+// Refund item types, PRODUCT and GIFT_WRAPPING items point at an order product id, SHIPPING and HANDLING items at
+// an order address id and ORDER items at the order id
+const (
+	RefundItemProduct      = "PRODUCT"
+	RefundItemGiftWrapping = "GIFT_WRAPPING"
+	RefundItemShipping     = "SHIPPING"
+	RefundItemHandling     = "HANDLING"
+	RefundItemOrder        = "ORDER"
+	RefundItemFee          = "FEE"
+)
+
+// RefundItem is a line of a refund as BigCommerce returns it
+type RefundItem struct {
+	ItemType        string          `json:"item_type"`
+	ItemID          int64           `json:"item_id"`
+	Quantity        int64           `json:"quantity"`
+	RequestedAmount primative.Money `json:"requested_amount"`
+	Reason          string          `json:"reason"`
+}
+
+// RefundPayment is the part of a refund paid back through one payment provider
+type RefundPayment struct {
+	ID              int64           `json:"id"`
+	ProviderID      string          `json:"provider_id"`
+	Amount          primative.Money `json:"amount"`
+	Offline         bool            `json:"offline"`
+	IsDeclined      bool            `json:"is_declined"`
+	DeclinedMessage string          `json:"declined_message"`
+}
+
+// Refund is a struct that represents a BigCommerce V3 order refund
+type Refund struct {
+	ID                         int64           `json:"id"`
+	OrderID                    int64           `json:"order_id"`
+	UserID                     int64           `json:"user_id"`
+	Created                    time.Time       `json:"created"`
+	Reason                     string          `json:"reason"`
+	TotalAmount                primative.Money `json:"total_amount"`
+	TotalTax                   primative.Money `json:"total_tax"`
+	UsesMerchantOverrideValues bool            `json:"uses_merchant_override_values"`
+	Payments                   []RefundPayment `json:"payments"`
+	Items                      []RefundItem    `json:"items"`
+}
+
+// RefundItemRequest is a line to quote or refund. Quantity is used for PRODUCT items, Amount for SHIPPING, HANDLING
+// and ORDER items
+type RefundItemRequest struct {
+	ItemType string           `json:"item_type"`
+	ItemID   int64            `json:"item_id"`
+	Quantity int64            `json:"quantity,omitempty"`
+	Amount   *primative.Money `json:"amount,omitempty"`
+	Reason   string           `json:"reason,omitempty"`
+}
+
+// RefundQuoteRequest is the body of BigCommerce POST /orders/{id}/payment_actions/refund_quotes
+type RefundQuoteRequest struct {
+	Items []RefundItemRequest `json:"items"`
+}
+
+// RefundPaymentRequest pays back part of a refund through one of the providers offered by a RefundQuote
+type RefundPaymentRequest struct {
+	ProviderID string          `json:"provider_id"`
+	Amount     primative.Money `json:"amount"`
+	Offline    bool            `json:"offline"`
+}
+
+// RefundRequest is the body of BigCommerce POST /orders/{id}/payment_actions/refunds
+type RefundRequest struct {
+	Items    []RefundItemRequest    `json:"items"`
+	Payments []RefundPaymentRequest `json:"payments"`
+}
+
+// RefundMethodPayment is one of the payments making up a refund method of a quote
+type RefundMethodPayment struct {
+	ProviderID          string          `json:"provider_id"`
+	ProviderDescription string          `json:"provider_description"`
+	Amount              primative.Money `json:"amount"`
+	Offline             bool            `json:"offline"`
+	OfflineProvider     bool            `json:"offline_provider"`
+	OfflineReason       string          `json:"offline_reason"`
+}
+
+// RefundQuote is what BigCommerce would refund for a RefundQuoteRequest. Each entry of RefundMethods is one way of
+// paying the refund back, made up of one or more payments that together cover TotalRefundAmount
+type RefundQuote struct {
+	OrderID              int64                   `json:"order_id"`
+	TotalRefundAmount    primative.Money         `json:"total_refund_amount"`
+	TotalRefundTaxAmount primative.Money         `json:"total_refund_tax_amount"`
+	Rounding             primative.Money         `json:"rounding"`
+	Adjustment           primative.Money         `json:"adjustment"`
+	TaxInclusive         bool                    `json:"tax_inclusive"`
+	RefundMethods        [][]RefundMethodPayment `json:"refund_methods"`
+}
+
+// RefundRequest builds the refund for the quoted items, paid back using the refund method at index method
+func (q RefundQuote) RefundRequest(items []RefundItemRequest, method int) (RefundRequest, error) {
+	if method < 0 || method >= len(q.RefundMethods) {
+		return RefundRequest{}, fmt.Errorf("order: refund quote has no refund method %d", method)
+	}
+	payments := make([]RefundPaymentRequest, 0, len(q.RefundMethods[method]))
+	for _, p := range q.RefundMethods[method] {
+		payments = append(payments, RefundPaymentRequest{
+			ProviderID: p.ProviderID,
+			Amount:     p.Amount,
+			Offline:    p.Offline,
+		})
+	}
+	return RefundRequest{Items: items, Payments: payments}, nil
+}
+
+// RefundQuery struct to handle the store wide refunds endpoint search query params
+type RefundQuery struct {
+	OrderIDs      []int     `url:"order_id:in,comma,omitempty"`
+	IDs           []int     `url:"id:in,comma,omitempty"`
+	MinCreated    time.Time `url:"-"`
+	MaxCreated    time.Time `url:"-"`
+	Page          int       `url:"page,omitempty"`
+	Limit         int       `url:"limit,omitempty"`
+	MinCreatedRaw string    `url:"created:min,omitempty"`
+	MaxCreatedRaw string    `url:"created:max,omitempty"`
+}
+
+// GetRawQuery gets the struct in query string form
+func (q RefundQuery) GetRawQuery() (string, error) {
+	if !q.MinCreated.IsZero() {
+		q.MinCreatedRaw = q.MinCreated.Format(time.RFC3339)
+	}
+	if !q.MaxCreated.IsZero() {
+		q.MaxCreatedRaw = q.MaxCreated.Format(time.RFC3339)
+	}
+	v, err := query.Values(q)
+	if err != nil {
+		return "", err
+	}
+	return v.Encode(), nil
+}
+
+// GetRefundQuote will ask BigCommerce what refunding the requested items would come to and how it can be paid back,
+// nothing is refunded
+func (s *Client) GetRefundQuote(orderID int, rq RefundQuoteRequest) (*RefundQuote, error) {
+	return s.GetRefundQuoteContext(context.Background(), orderID, rq)
+}
+
+// GetRefundQuoteContext - same as GetRefundQuote but the request is cancelled when ctx is done
+func (s *Client) GetRefundQuoteContext(ctx context.Context, orderID int, rq RefundQuoteRequest) (*RefundQuote, error) {
+	var data RefundQuote
+	url := fmt.Sprintf("v3/orders/%d/payment_actions/refund_quotes", orderID)
+	_, err := s.DoV3Context(ctx, "POST", url, "", rq, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CreateRefund will refund the items of the order, use GetRefundQuote and RefundQuote.RefundRequest to work out the
+// payments
+func (s *Client) CreateRefund(orderID int, rr RefundRequest) (*Refund, error) {
+	return s.CreateRefundContext(context.Background(), orderID, rr)
+}
+
+// CreateRefundContext - same as CreateRefund but the request is cancelled when ctx is done
+func (s *Client) CreateRefundContext(ctx context.Context, orderID int, rr RefundRequest) (*Refund, error) {
+	var data Refund
+	url := fmt.Sprintf("v3/orders/%d/payment_actions/refunds", orderID)
+	_, err := s.DoV3Context(ctx, "POST", url, "", rr, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetRefunds will return every refund made against the order
+func (s *Client) GetRefunds(orderID int) ([]Refund, error) {
+	return s.GetRefundsContext(context.Background(), orderID)
+}
+
+// GetRefundsContext - same as GetRefunds but the requests are cancelled when ctx is done
+func (s *Client) GetRefundsContext(ctx context.Context, orderID int) ([]Refund, error) {
+	var data []Refund
+	url := fmt.Sprintf("v3/orders/%d/payment_actions/refunds", orderID)
+	err := s.GetAllPagesContext(ctx, url, "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetAllRefunds will return the refunds across the whole store matching rq, every page is read unless rq.Page is set
+func (s *Client) GetAllRefunds(rq RefundQuery) ([]Refund, error) {
+	return s.GetAllRefundsContext(context.Background(), rq)
+}
+
+// GetAllRefundsContext - same as GetAllRefunds, cancelling ctx stops fetching any further pages
+func (s *Client) GetAllRefundsContext(ctx context.Context, rq RefundQuery) ([]Refund, error) {
+	rawQuery, err := rq.GetRawQuery()
+	if err != nil {
+		return nil, err
+	}
+	var data []Refund
+	if rq.Page != 0 {
+		_, err = s.GetV3AndUnmarshalContext(ctx, "v3/orders/payment_actions/refunds", rawQuery, &data)
+	} else {
+		err = s.GetAllPagesContext(ctx, "v3/orders/payment_actions/refunds", rawQuery, &data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
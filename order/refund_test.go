@@ -0,0 +1,139 @@
+package order_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dan-collins/biggommerce/order"
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+// v3Server is a fake of the V3 order endpoints, it answers every request with the response handler returns for it
+// and keeps the requests it got
+type v3Server struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   []string
+}
+
+func (v *v3Server) log() ([]*http.Request, []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]*http.Request(nil), v.requests...), append([]string(nil), v.bodies...)
+}
+
+func newV3Client(t *testing.T, handler func(r *http.Request) (int, string)) (*order.Client, *v3Server) {
+	t.Helper()
+	v := &v3Server{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		v.mu.Lock()
+		v.requests = append(v.requests, r)
+		v.bodies = append(v.bodies, string(body))
+		v.mu.Unlock()
+		status, resp := handler(r)
+		w.WriteHeader(status)
+		w.Write([]byte(resp))
+	}))
+	t.Cleanup(srv.Close)
+	c := order.NewClient("token", "client", "store")
+	c.SetBaseURL(srv.URL + "/")
+	c.Limiter = nil
+	return c, v
+}
+
+func TestRefundQuoteAndCreate(t *testing.T) {
+	quote := `{"data":{"order_id":100,"total_refund_amount":25.5,"total_refund_tax_amount":2.32,"rounding":0,"adjustment":0,
+		"tax_inclusive":true,"refund_methods":[[{"provider_id":"storecredit","amount":25.5,"offline":false}],
+		[{"provider_id":"braintree","amount":20,"offline":false},{"provider_id":"custom","amount":5.5,"offline":true}]]}}`
+	refund := `{"data":{"id":9,"order_id":100,"created":"2024-03-01T12:00:00Z","total_amount":25.5,"total_tax":2.32,
+		"payments":[{"id":1,"provider_id":"braintree","amount":20},{"id":2,"provider_id":"custom","amount":5.5,"offline":true}],
+		"items":[{"item_type":"PRODUCT","item_id":5,"quantity":1,"requested_amount":null}]}}`
+	c, v := newV3Client(t, func(r *http.Request) (int, string) {
+		switch r.URL.Path {
+		case "/store/v3/orders/100/payment_actions/refund_quotes":
+			return http.StatusCreated, quote
+		case "/store/v3/orders/100/payment_actions/refunds":
+			return http.StatusCreated, refund
+		}
+		return http.StatusNotFound, `{"status":404,"title":"not found"}`
+	})
+
+	items := []order.RefundItemRequest{{ItemType: order.RefundItemProduct, ItemID: 5, Quantity: 1}}
+	q, err := c.GetRefundQuote(100, order.RefundQuoteRequest{Items: items})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.TotalRefundAmount.Equal(primative.MustParseMoney("25.50")) || len(q.RefundMethods) != 2 || len(q.RefundMethods[1]) != 2 {
+		t.Fatalf("quote decoded as %+v", q)
+	}
+	if _, err := q.RefundRequest(items, 2); err == nil {
+		t.Error("a refund method the quote does not have should be refused")
+	}
+	rr, err := q.RefundRequest(items, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := c.CreateRefund(100, rr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != 9 || !r.Created.Equal(time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)) || len(r.Payments) != 2 || !r.Payments[1].Offline {
+		t.Errorf("refund decoded as %+v", r)
+	}
+
+	requests, bodies := v.log()
+	if len(requests) != 2 || requests[0].Method != "POST" || requests[1].Method != "POST" {
+		t.Fatalf("sent %d requests, want the quote and the refund posted", len(requests))
+	}
+	var sent order.RefundRequest
+	if err := json.Unmarshal([]byte(bodies[1]), &sent); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent.Items) != 1 || len(sent.Payments) != 2 || sent.Payments[1].ProviderID != "custom" || !sent.Payments[1].Offline ||
+		!sent.Payments[0].Amount.Equal(primative.MustParseMoney("20")) {
+		t.Errorf("refund was sent as %s, want the items and the payments of refund method 1", bodies[1])
+	}
+}
+
+func TestGetAllRefunds(t *testing.T) {
+	c, v := newV3Client(t, func(r *http.Request) (int, string) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		id := map[string]int{"1": 1, "2": 2}[page]
+		return http.StatusOK, fmt.Sprintf(`{"data":[{"id":%d,"order_id":100,"total_amount":"1.00"}],
+			"meta":{"pagination":{"total":2,"count":1,"per_page":1,"current_page":%s,"total_pages":2}}}`, id, page)
+	})
+
+	min := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	refunds, err := c.GetAllRefunds(order.RefundQuery{OrderIDs: []int{100, 101}, MinCreated: min})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refunds) != 2 || refunds[0].ID != 1 || refunds[1].ID != 2 {
+		t.Fatalf("got %+v, want both pages of refunds", refunds)
+	}
+	requests, _ := v.log()
+	for _, r := range requests {
+		q := r.URL.Query()
+		if r.URL.Path != "/store/v3/orders/payment_actions/refunds" || q.Get("order_id:in") != "100,101" || q.Get("created:min") != "2024-03-01T00:00:00Z" {
+			t.Errorf("asked for %s, want the store wide refunds filtered on every page", r.URL)
+		}
+	}
+
+	refunds, err = c.GetAllRefunds(order.RefundQuery{Page: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests, _ = v.log(); len(refunds) != 1 || refunds[0].ID != 2 || len(requests) != 3 {
+		t.Errorf("asking for page 2 got %+v after %d requests, want just that page", refunds, len(requests))
+	}
+}
@@ -1,6 +1,11 @@
 package order
 
-import "github.com/dan-collins/biggommerce/primative"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dan-collins/biggommerce/primative"
+)
 
 // Shipment is a struct that represents BC shipment
 type Shipment struct {
@@ -10,7 +15,7 @@ type Shipment struct {
 	OrderAddressID       int64               `json:"order_address_id"`
 	DateCreated          primative.BCDate    `json:"date_created"`
 	TrackingNumber       string              `json:"tracking_number"`
-	MerchantShippingCost float64             `json:"merchant_shipping_cost,string"`
+	MerchantShippingCost primative.Money     `json:"merchant_shipping_cost"`
 	ShippingMethod       string              `json:"shipping_method"`
 	Comments             string              `json:"comments"`
 	ShippingProvider     string              `json:"shipping_provider"`
@@ -27,3 +32,84 @@ type ShipmentOrderItem struct {
 	ProductID      int64 `json:"product_id"`
 	Quantity       int64 `json:"quantity"`
 }
+
+// ShipmentCreate is the body of BigCommerce POST /orders/{id}/shipments, OrderAddressID and Items are required
+type ShipmentCreate struct {
+	OrderAddressID   int64               `json:"order_address_id"`
+	TrackingNumber   string              `json:"tracking_number,omitempty"`
+	ShippingMethod   string              `json:"shipping_method,omitempty"`
+	ShippingProvider string              `json:"shipping_provider,omitempty"`
+	TrackingCarrier  string              `json:"tracking_carrier,omitempty"`
+	Comments         string              `json:"comments,omitempty"`
+	Items            []ShipmentItemWrite `json:"items"`
+	// ShipRefunded lets refunded units be shipped. BigCommerce does not say whether a refunded unit was sent back or
+	// never left, so by default CreateShipment takes refunded units off what is left to ship to be safe against
+	// shipping goods the customer got their money back for. Set it when partial refunds are given without a return,
+	// e.g. as a discount for damaged goods, and the units still have to go out. It is never sent to BigCommerce
+	ShipRefunded bool `json:"-"`
+}
+
+// ShipmentUpdate is the body of BigCommerce PUT /orders/{id}/shipments/{id}, only the fields that are set are sent
+type ShipmentUpdate struct {
+	OrderAddressID   *int64  `json:"order_address_id,omitempty"`
+	TrackingNumber   *string `json:"tracking_number,omitempty"`
+	ShippingMethod   *string `json:"shipping_method,omitempty"`
+	ShippingProvider *string `json:"shipping_provider,omitempty"`
+	TrackingCarrier  *string `json:"tracking_carrier,omitempty"`
+	Comments         *string `json:"comments,omitempty"`
+}
+
+// ShipmentItemWrite is a quantity of one order product to put in a shipment
+type ShipmentItemWrite struct {
+	OrderProductID int64 `json:"order_product_id"`
+	Quantity       int64 `json:"quantity"`
+}
+
+// Validate checks the fields BigCommerce requires to create a shipment are filled in
+func (sc ShipmentCreate) Validate() error {
+	if sc.OrderAddressID == 0 {
+		return errors.New("order: shipment order_address_id is required")
+	}
+	if len(sc.Items) == 0 {
+		return errors.New("order: shipment needs at least one item")
+	}
+	for i, item := range sc.Items {
+		if item.OrderProductID == 0 {
+			return fmt.Errorf("order: shipment items[%d]: order_product_id is required", i)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("order: shipment items[%d]: quantity must be at least 1", i)
+		}
+	}
+	return nil
+}
+
+// validateAgainst checks every item is a product of the order, ships to the shipment's address and that no more
+// than what is left of it is being shipped, the quantity ordered less what was already shipped and, unless
+// ShipRefunded is set, what was refunded
+func (sc ShipmentCreate) validateAgainst(products []OrderProduct) error {
+	byID := make(map[int64]OrderProduct, len(products))
+	for _, p := range products {
+		byID[p.ID] = p
+	}
+	requested := make(map[int64]int64, len(sc.Items))
+	for i, item := range sc.Items {
+		p, ok := byID[item.OrderProductID]
+		if !ok {
+			return fmt.Errorf("order: shipment items[%d]: order product %d is not part of the order", i, item.OrderProductID)
+		}
+		if p.OrderAddressID != 0 && p.OrderAddressID != sc.OrderAddressID {
+			return fmt.Errorf("order: shipment items[%d]: order product %d ships to address %d not %d",
+				i, p.ID, p.OrderAddressID, sc.OrderAddressID)
+		}
+		requested[p.ID] += item.Quantity
+		left := p.Quantity - p.QuantityShipped
+		if !sc.ShipRefunded {
+			left -= p.QuantityRefunded
+		}
+		if requested[p.ID] > left {
+			return fmt.Errorf("order: shipment items[%d]: order product %d only has %d left to ship", i, p.ID, left)
+		}
+	}
+	return nil
+}
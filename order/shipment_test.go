@@ -0,0 +1,40 @@
+package order
+
+import "testing"
+
+func TestShipmentValidateAgainst(t *testing.T) {
+	products := []OrderProduct{
+		{ID: 1, OrderAddressID: 10, Quantity: 3, QuantityShipped: 1, QuantityRefunded: 1},
+		{ID: 2, OrderAddressID: 10, Quantity: 2},
+	}
+	tests := []struct {
+		name  string
+		items []ShipmentItemWrite
+		ok    bool
+	}{
+		{"what is left", []ShipmentItemWrite{{OrderProductID: 1, Quantity: 1}}, true},
+		{"refunded units", []ShipmentItemWrite{{OrderProductID: 1, Quantity: 2}}, false},
+		{"split over items", []ShipmentItemWrite{{OrderProductID: 2, Quantity: 1}, {OrderProductID: 2, Quantity: 2}}, false},
+		{"not on the order", []ShipmentItemWrite{{OrderProductID: 3, Quantity: 1}}, false},
+	}
+	for _, tt := range tests {
+		sc := ShipmentCreate{OrderAddressID: 10, Items: tt.items}
+		err := sc.validateAgainst(products)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v", tt.name, err)
+		}
+	}
+
+	refunded := ShipmentCreate{OrderAddressID: 10, ShipRefunded: true, Items: []ShipmentItemWrite{{OrderProductID: 1, Quantity: 2}}}
+	if err := refunded.validateAgainst(products); err != nil {
+		t.Errorf("refunded units should be shippable with ShipRefunded: %v", err)
+	}
+	refunded.Items[0].Quantity = 3
+	if refunded.validateAgainst(products) == nil {
+		t.Error("ShipRefunded should still not ship units that already shipped")
+	}
+	sc := ShipmentCreate{OrderAddressID: 11, Items: []ShipmentItemWrite{{OrderProductID: 2, Quantity: 1}}}
+	if sc.validateAgainst(products) == nil {
+		t.Error("shipping a product to another address should fail")
+	}
+}
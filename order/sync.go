@@ -0,0 +1,167 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultSyncOverlap is how far before its high-water mark a Syncer starts each sync when Overlap is not set
+const DefaultSyncOverlap = 5 * time.Minute
+
+// SyncFunc is handed every new or changed order, returning an error stops the sync
+type SyncFunc func(ctx context.Context, o Order) error
+
+// Syncer polls for orders that were created or modified since it last ran and hands each of them to a SyncFunc once
+// per version, a version being the order's date modified.
+//
+// BigCommerce only filters on whole seconds, and orders can show up in the listing a little after their date modified,
+// so every sync starts Overlap before the latest date modified handed out so far. The versions handed out within that
+// window are kept in the Checkpoint, which is saved to the Store after every order, so nothing is handed out twice
+// even across restarts. Older versions are dropped from the Checkpoint as the high-water mark moves on, so it stays
+// the size of the window however many orders are synced. Overlap should be longer than a sync takes to run.
+//
+//	syncer := order.NewSyncer(client, order.NewFileCheckpointStore("orders.checkpoint"))
+//	syncer.Query = order.Query{StatusID: 11}
+//	n, err := syncer.Sync(func(ctx context.Context, o order.Order) error {
+//		return publish(o)
+//	})
+type Syncer struct {
+	Client *Client
+	Store  CheckpointStore
+	// Query filters the orders synced. Its MinDateModified is where the first sync starts, every order when it is
+	// zero, the date modified bounds, page and sort are otherwise set by the Syncer
+	Query Query
+	// Overlap is how far before the high-water mark each sync starts, DefaultSyncOverlap when 0
+	Overlap time.Duration
+
+	mu sync.Mutex
+}
+
+// NewSyncer returns a Syncer for every order of the store, keeping its checkpoint in store
+func NewSyncer(client *Client, store CheckpointStore) *Syncer {
+	return &Syncer{Client: client, Store: store}
+}
+
+// Sync hands every order created or modified since the last sync to fn, oldest date modified first, and returns how
+// many it handed out. When fn fails the sync stops there and the order it failed on is handed out again next time
+func (s *Syncer) Sync(fn SyncFunc) (int, error) {
+	return s.SyncContext(context.Background(), fn)
+}
+
+// SyncContext - same as Sync, cancelling ctx stops the sync after the order being handed out
+func (s *Syncer) SyncContext(ctx context.Context, fn SyncFunc) (int, error) {
+	if s.Client == nil || s.Store == nil {
+		return 0, errors.New("order: syncer needs a Client and a Store")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp, err := s.Store.Load(ctx)
+	if err != nil {
+		return 0, err
+	}
+	cp = copyCheckpoint(cp)
+	from := s.Query.MinDateModified
+	if !cp.DateModified.IsZero() {
+		from = cp.DateModified.Add(-s.overlap())
+	}
+	// the api ignores anything below a second
+	from = from.Truncate(time.Second)
+	s.prune(cp, from)
+
+	oq := s.Query
+	oq.MaxDateModified, oq.MaxDateModifiedRaw = time.Time{}, ""
+	oq.Sort = "date_modified:asc"
+	if oq.Limit <= 0 {
+		oq.Limit = s.Client.Limit
+	}
+	if oq.Limit <= 0 {
+		// the page size BigCommerce uses when none is asked for
+		oq.Limit = 50
+	}
+
+	// pages are read from a cursor on date modified rather than by page number, orders modified while the sync runs
+	// move to the end of the listing and would shift every later page under a page number. Only when a whole page
+	// shares the cursor's second does the page number go up
+	synced := 0
+	cursor, page := from, 1
+	for {
+		oq.MinDateModified, oq.MinDateModifiedRaw = cursor, ""
+		oq.Page = page
+		orders, err := s.readPage(ctx, oq)
+		if err != nil {
+			return synced, err
+		}
+		for _, o := range orders {
+			modified := o.DateModified.Time
+			if seen, ok := cp.Seen[o.ID]; ok && !modified.After(seen) {
+				continue
+			}
+			err = fn(ctx, o)
+			if err != nil {
+				return synced, err
+			}
+			synced++
+			cp.Seen[o.ID] = modified
+			if modified.After(cp.DateModified) || (modified.Equal(cp.DateModified) && o.ID > cp.ID) {
+				cp.DateModified, cp.ID = modified, o.ID
+			}
+			s.prune(cp, cursor)
+			err = s.Store.Save(ctx, cp)
+			if err != nil {
+				return synced, err
+			}
+			if ctx.Err() != nil {
+				return synced, ctx.Err()
+			}
+		}
+		if len(orders) < oq.Limit {
+			if synced == 0 {
+				return 0, nil
+			}
+			// nothing behind the window is read again, so the last page's orders before it can go too
+			s.prune(cp, cp.DateModified)
+			return synced, s.Store.Save(ctx, cp)
+		}
+		last := orders[len(orders)-1].DateModified.Time.Truncate(time.Second)
+		if last.After(cursor) {
+			cursor, page = last, 1
+		} else {
+			page++
+		}
+	}
+}
+
+// readPage reads the single page of orders oq asks for, in the order the api returns them
+func (s *Syncer) readPage(ctx context.Context, oq Query) ([]Order, error) {
+	it := s.Client.IterateOrders(oq)
+	defer it.Close()
+	var orders []Order
+	for it.Next(ctx) {
+		orders = append(orders, it.Order())
+	}
+	return orders, it.Err()
+}
+
+// prune drops the orders seen before the overlap window of the high-water mark, or before cursor when the sync is
+// still reading further back, so the checkpoint only ever holds a window worth of orders
+func (s *Syncer) prune(cp Checkpoint, cursor time.Time) {
+	cutoff := cp.DateModified.Add(-s.overlap()).Truncate(time.Second)
+	if cursor.Before(cutoff) {
+		cutoff = cursor
+	}
+	for id, modified := range cp.Seen {
+		if modified.Before(cutoff) {
+			delete(cp.Seen, id)
+		}
+	}
+}
+
+func (s *Syncer) overlap() time.Duration {
+	if s.Overlap > 0 {
+		return s.Overlap
+	}
+	return DefaultSyncOverlap
+}
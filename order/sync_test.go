@@ -0,0 +1,108 @@
+package order_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dan-collins/biggommerce/bctest"
+	"github.com/dan-collins/biggommerce/order"
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+var syncStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func modifiedAt(t time.Time) primative.BCDate {
+	return primative.BCDate{Time: t}
+}
+
+func TestSyncOrdersModifiedWhileSyncing(t *testing.T) {
+	srv := bctest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 120; i++ {
+		srv.AddOrder(order.Order{StatusID: 11, DateModified: modifiedAt(syncStart.Add(time.Duration(i) * time.Minute))})
+	}
+	c := srv.Client()
+	c.Limit = 50
+	store := &order.MemoryCheckpointStore{}
+	syncer := order.NewSyncer(c, store)
+	syncer.Query.MinDateModified = syncStart
+
+	var first int64
+	handedOut := map[int64]int{}
+	n, err := syncer.Sync(func(ctx context.Context, o order.Order) error {
+		if first == 0 {
+			first = o.ID
+		}
+		handedOut[o.ID]++
+		if len(handedOut) == 10 && handedOut[o.ID] == 1 {
+			// the first order changes while the sync is part way through the first page, a page number based sync
+			// would see every later order shift down one place and skip one
+			srv.AddOrder(order.Order{ID: first, StatusID: 11, DateModified: modifiedAt(syncStart.Add(200 * time.Minute))})
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 121 || len(handedOut) != 120 || handedOut[first] != 2 {
+		t.Fatalf("synced %d versions of %d orders, the changed one %d times, want 121 of 120 with it twice", n, len(handedOut), handedOut[first])
+	}
+	for id, times := range handedOut {
+		if id != first && times != 1 {
+			t.Errorf("order %d was handed out %d times", id, times)
+		}
+	}
+
+	cp, err := store.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cp.DateModified.Equal(syncStart.Add(200*time.Minute)) || cp.ID != first {
+		t.Errorf("high-water mark is order %d at %s, want order %d at +200m", cp.ID, cp.DateModified, first)
+	}
+	// once the sync is done only what falls within the overlap window of the high-water mark is kept
+	if len(cp.Seen) != 1 {
+		t.Errorf("checkpoint holds %d orders, want the 1 within the overlap window", len(cp.Seen))
+	}
+
+	n, err = syncer.Sync(func(ctx context.Context, o order.Order) error {
+		t.Errorf("order %d was handed out again", o.ID)
+		return nil
+	})
+	if err != nil || n != 0 {
+		t.Errorf("second sync handed out %d orders (%v), want none", n, err)
+	}
+}
+
+func TestSyncPagesWithinOneSecond(t *testing.T) {
+	srv := bctest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 60; i++ {
+		srv.AddOrder(order.Order{StatusID: 11, DateModified: modifiedAt(syncStart)})
+	}
+	srv.AddOrder(order.Order{StatusID: 11, DateModified: modifiedAt(syncStart.Add(time.Second))})
+	c := srv.Client()
+	c.Limit = 25
+	store := &order.MemoryCheckpointStore{}
+	syncer := order.NewSyncer(c, store)
+
+	handedOut := map[int64]int{}
+	n, err := syncer.Sync(func(ctx context.Context, o order.Order) error {
+		handedOut[o.ID]++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 61 || len(handedOut) != 61 {
+		t.Fatalf("synced %d versions of %d orders, want all 61 once", n, len(handedOut))
+	}
+	cp, err := store.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cp.Seen) != 61 {
+		t.Errorf("checkpoint holds %d orders, want all 61 as they are within the overlap window", len(cp.Seen))
+	}
+}
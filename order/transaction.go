@@ -0,0 +1,144 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dan-collins/biggommerce/connect"
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+// Transaction events
+const (
+	TransactionPurchase      = "purchase"
+	TransactionAuthorization = "authorization"
+	TransactionCapture       = "capture"
+	TransactionRefund        = "refund"
+	TransactionVoid          = "void"
+	TransactionPending       = "pending"
+	TransactionSettled       = "settled"
+)
+
+// Transaction methods, the variant detail structs on Transaction are only filled for their method
+const (
+	PaymentMethodCreditCard       = "credit_card"
+	PaymentMethodElectronicWallet = "electronic_wallet"
+	PaymentMethodGiftCertificate  = "gift_certificate"
+	PaymentMethodStoreCredit      = "store_credit"
+	PaymentMethodApplePayCard     = "apple_pay_card"
+	PaymentMethodBigpayToken      = "bigpay_token"
+	PaymentMethodApplePayToken    = "apple_pay_token"
+	PaymentMethodToken            = "token"
+	PaymentMethodCustom           = "custom"
+	PaymentMethodOffline          = "offline"
+	PaymentMethodNonce            = "nonce"
+	PaymentMethodACH              = "ach"
+)
+
+// Transaction is a struct that represents a BigCommerce V3 order transaction, the gateway record of a payment event
+type Transaction struct {
+	ID                     int64                       `json:"id"`
+	OrderID                string                      `json:"order_id"`
+	Event                  string                      `json:"event"`
+	Method                 string                      `json:"method"`
+	Amount                 primative.Money             `json:"amount"`
+	Currency               string                      `json:"currency"`
+	Gateway                string                      `json:"gateway"`
+	GatewayTransactionID   string                      `json:"gateway_transaction_id"`
+	PaymentMethodID        string                      `json:"payment_method_id"`
+	PaymentInstrumentToken string                      `json:"payment_instrument_token"`
+	DateCreated            time.Time                   `json:"date_created"`
+	Test                   bool                        `json:"test"`
+	Status                 string                      `json:"status"`
+	FraudReview            bool                        `json:"fraud_review"`
+	ReferenceTransactionID int64                       `json:"reference_transaction_id"`
+	AVSResult              *AVSResult                  `json:"avs_result,omitempty"`
+	CVVResult              *CVVResult                  `json:"cvv_result,omitempty"`
+	CreditCard             *TransactionCreditCard      `json:"credit_card,omitempty"`
+	GiftCertificate        *TransactionGiftCertificate `json:"gift_certificate,omitempty"`
+	StoreCredit            *TransactionStoreCredit     `json:"store_credit,omitempty"`
+	Offline                *TransactionOffline         `json:"offline,omitempty"`
+	Custom                 *TransactionCustom          `json:"custom,omitempty"`
+}
+
+// AVSResult is the address verification result the gateway returned
+type AVSResult struct {
+	Code        string `json:"code"`
+	Message     string `json:"message"`
+	StreetMatch string `json:"street_match"`
+	PostalMatch string `json:"postal_match"`
+}
+
+// CVVResult is the card verification value result the gateway returned
+type CVVResult struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// TransactionCreditCard is the card metadata of a credit card transaction
+type TransactionCreditCard struct {
+	CardType        string `json:"card_type"`
+	CardIIN         string `json:"card_iin"`
+	CardLast4       string `json:"card_last4"`
+	CardExpiryMonth int    `json:"card_expiry_month"`
+	CardExpiryYear  int    `json:"card_expiry_year"`
+}
+
+// TransactionGiftCertificate is the gift certificate used by a gift certificate transaction
+type TransactionGiftCertificate struct {
+	Code             string          `json:"code"`
+	OriginalBalance  primative.Money `json:"original_balance"`
+	StartingBalance  primative.Money `json:"starting_balance"`
+	RemainingBalance primative.Money `json:"remaining_balance"`
+	Status           string          `json:"status"`
+}
+
+// TransactionStoreCredit is the customer's store credit left after a store credit transaction
+type TransactionStoreCredit struct {
+	RemainingBalance primative.Money `json:"remaining_balance"`
+}
+
+// TransactionOffline describes an offline payment such as cash on delivery
+type TransactionOffline struct {
+	DisplayName string `json:"display_name"`
+}
+
+// TransactionCustom describes a payment recorded manually with a custom payment method
+type TransactionCustom struct {
+	PaymentMethod string `json:"payment_method"`
+}
+
+// GetTransactions will return the payment gateway transactions of the order
+func (s *Client) GetTransactions(orderID int) ([]Transaction, error) {
+	return s.GetTransactionsContext(context.Background(), orderID)
+}
+
+// GetTransactionsContext - same as GetTransactions but the requests are cancelled when ctx is done
+func (s *Client) GetTransactionsContext(ctx context.Context, orderID int) ([]Transaction, error) {
+	var data []Transaction
+	err := s.GetAllPagesContext(ctx, fmt.Sprintf("v3/orders/%d/transactions", orderID), "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetTransactionsForOrders - Will attempt to concurrently fill the order slice elements with their respective transactions from the BC api
+func (s *Client) GetTransactionsForOrders(os []Order) (err error) {
+	return s.GetTransactionsForOrdersContext(context.Background(), os)
+}
+
+// GetTransactionsForOrdersContext - same as GetTransactionsForOrders, cancelling ctx stops any outstanding requests
+func (s *Client) GetTransactionsForOrdersContext(ctx context.Context, os []Order) (err error) {
+	ctx, span := s.startBatch(ctx, "GetTransactionsForOrders", len(os))
+	defer func() { connect.EndSpan(span, err) }()
+	return s.forEachOrder(ctx, os, func(ctx context.Context, o *Order) error {
+		transactions, err := s.GetTransactionsContext(ctx, int(o.ID))
+		if err != nil {
+			return err
+		}
+		o.Transactions = transactions
+		return nil
+	})
+}
@@ -0,0 +1,74 @@
+package order_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dan-collins/biggommerce/connect"
+	"github.com/dan-collins/biggommerce/order"
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+const transactionsPage = `{"data":[
+	{"id":1,"order_id":"%[1]d","event":"purchase","method":"credit_card","amount":49.99,"currency":"USD","gateway":"braintree",
+		"date_created":"2024-03-01T12:00:00+00:00","status":"ok",
+		"avs_result":{"code":"M","message":"Street and postal match","street_match":"Y","postal_match":"Y"},
+		"cvv_result":{"code":"M","message":"Matched"},
+		"credit_card":{"card_type":"visa","card_iin":"411111","card_last4":"1111","card_expiry_month":12,"card_expiry_year":2030}},
+	{"id":2,"order_id":"%[1]d","event":"purchase","method":"gift_certificate","amount":10,"currency":"USD","gateway":"giftcertificate",
+		"date_created":"2024-03-01T12:00:00+00:00","status":"ok",
+		"gift_certificate":{"code":"GIFT-1","original_balance":50,"starting_balance":20,"remaining_balance":10,"status":"active"}}
+],"meta":{"pagination":{"total":2,"count":2,"current_page":1,"total_pages":1}}}`
+
+func TestGetTransactionsForOrders(t *testing.T) {
+	c, v := newV3Client(t, func(r *http.Request) (int, string) {
+		var id int
+		fmt.Sscanf(r.URL.Path, "/store/v3/orders/%d/transactions", &id)
+		return http.StatusOK, fmt.Sprintf(transactionsPage, id)
+	})
+	orders := []order.Order{{ID: 100}, {ID: 101}, {ID: 102}}
+
+	err := c.GetTransactionsForOrders(orders)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests, _ := v.log(); len(requests) != 3 {
+		t.Errorf("sent %d requests, want one per order", len(requests))
+	}
+	for _, o := range orders {
+		if len(o.Transactions) != 2 || o.Transactions[0].OrderID != fmt.Sprint(o.ID) {
+			t.Fatalf("order %d got transactions %+v", o.ID, o.Transactions)
+		}
+	}
+
+	card := orders[0].Transactions[0]
+	if card.CreditCard == nil || card.CreditCard.CardLast4 != "1111" || card.CreditCard.CardExpiryYear != 2030 ||
+		card.AVSResult == nil || card.AVSResult.PostalMatch != "Y" || card.CVVResult == nil || card.GiftCertificate != nil {
+		t.Errorf("credit card transaction decoded as %+v", card)
+	}
+	if !card.Amount.Equal(primative.MustParseMoney("49.99")) || card.DateCreated.IsZero() {
+		t.Errorf("amount %s and date %s were not decoded", card.Amount, card.DateCreated)
+	}
+	gift := orders[0].Transactions[1]
+	if gift.GiftCertificate == nil || gift.CreditCard != nil || !gift.GiftCertificate.RemainingBalance.Equal(primative.MustParseMoney("10")) {
+		t.Errorf("gift certificate transaction decoded as %+v", gift)
+	}
+}
+
+func TestGetTransactionsForOrdersFails(t *testing.T) {
+	c, _ := newV3Client(t, func(r *http.Request) (int, string) {
+		if strings.Contains(r.URL.Path, "/101/") {
+			return http.StatusForbidden, `{"status":403,"title":"You don't have a required scope to access the endpoint"}`
+		}
+		return http.StatusOK, `{"data":[],"meta":{"pagination":{"total":0,"count":0,"current_page":1,"total_pages":1}}}`
+	})
+
+	err := c.GetTransactionsForOrders([]order.Order{{ID: 100}, {ID: 101}})
+	var apiErr *connect.APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("err = %v, want the 403 of order 101", err)
+	}
+}
@@ -0,0 +1,236 @@
+package order
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+// OrderCreate is the body of BigCommerce POST /orders, it only carries the fields BigCommerce lets you write.
+//
+// Products and BillingAddress are required, use Validate to check them before sending
+type OrderCreate struct {
+	CustomerID          int64               `json:"customer_id,omitempty"`
+	StatusID            int64               `json:"status_id,omitempty"`
+	BillingAddress      Address             `json:"billing_address"`
+	ShippingAddresses   []ShippingAddress   `json:"shipping_addresses,omitempty"`
+	Products            []OrderProductWrite `json:"products"`
+	BaseShippingCost    *primative.Money    `json:"base_shipping_cost,omitempty"`
+	ShippingCostExTax   *primative.Money    `json:"shipping_cost_ex_tax,omitempty"`
+	ShippingCostIncTax  *primative.Money    `json:"shipping_cost_inc_tax,omitempty"`
+	BaseHandlingCost    *primative.Money    `json:"base_handling_cost,omitempty"`
+	HandlingCostExTax   *primative.Money    `json:"handling_cost_ex_tax,omitempty"`
+	HandlingCostIncTax  *primative.Money    `json:"handling_cost_inc_tax,omitempty"`
+	DiscountAmount      *primative.Money    `json:"discount_amount,omitempty"`
+	PaymentMethod       string              `json:"payment_method,omitempty"`
+	PaymentProviderID   string              `json:"payment_provider_id,omitempty"`
+	StaffNotes          string              `json:"staff_notes,omitempty"`
+	CustomerMessage     string              `json:"customer_message,omitempty"`
+	OrderIsDigital      bool                `json:"order_is_digital,omitempty"`
+	IPAddress           string              `json:"ip_address,omitempty"`
+	DefaultCurrencyCode string              `json:"default_currency_code,omitempty"`
+	ExternalSource      string              `json:"external_source,omitempty"`
+	ExternalID          string              `json:"external_id,omitempty"`
+	ChannelID           int64               `json:"channel_id,omitempty"`
+}
+
+// OrderUpdate is the body of BigCommerce PUT /orders/{id}, only the fields that are set are sent so it can be used
+// as a patch. Products and shipping addresses with an ID change that line or address of the order, the ones without
+// are added to it
+type OrderUpdate struct {
+	CustomerID         *int64                  `json:"customer_id,omitempty"`
+	StatusID           *int64                  `json:"status_id,omitempty"`
+	BillingAddress     *Address                `json:"billing_address,omitempty"`
+	ShippingAddresses  []ShippingAddressUpdate `json:"shipping_addresses,omitempty"`
+	Products           []OrderProductUpdate    `json:"products,omitempty"`
+	BaseShippingCost   *primative.Money        `json:"base_shipping_cost,omitempty"`
+	ShippingCostExTax  *primative.Money        `json:"shipping_cost_ex_tax,omitempty"`
+	ShippingCostIncTax *primative.Money        `json:"shipping_cost_inc_tax,omitempty"`
+	BaseHandlingCost   *primative.Money        `json:"base_handling_cost,omitempty"`
+	HandlingCostExTax  *primative.Money        `json:"handling_cost_ex_tax,omitempty"`
+	HandlingCostIncTax *primative.Money        `json:"handling_cost_inc_tax,omitempty"`
+	DiscountAmount     *primative.Money        `json:"discount_amount,omitempty"`
+	PaymentMethod      *string                 `json:"payment_method,omitempty"`
+	PaymentProviderID  *string                 `json:"payment_provider_id,omitempty"`
+	StaffNotes         *string                 `json:"staff_notes,omitempty"`
+	CustomerMessage    *string                 `json:"customer_message,omitempty"`
+	OrderIsDigital     *bool                   `json:"order_is_digital,omitempty"`
+	ExternalSource     *string                 `json:"external_source,omitempty"`
+	ExternalID         *string                 `json:"external_id,omitempty"`
+}
+
+// OrderProductWrite is the writable part of OrderProduct. Catalog products need ProductID, custom products need
+// Name, PriceExTax and PriceIncTax instead
+type OrderProductWrite struct {
+	// ID is the order product id, only set it when updating an existing line of an order
+	ID             int64                `json:"id,omitempty"`
+	ProductID      int64                `json:"product_id,omitempty"`
+	Name           string               `json:"name,omitempty"`
+	NameCustomer   string               `json:"name_customer,omitempty"`
+	NameMerchant   string               `json:"name_merchant,omitempty"`
+	Sku            string               `json:"sku,omitempty"`
+	Upc            string               `json:"upc,omitempty"`
+	Quantity       int64                `json:"quantity"`
+	PriceExTax     *primative.Money     `json:"price_ex_tax,omitempty"`
+	PriceIncTax    *primative.Money     `json:"price_inc_tax,omitempty"`
+	ProductOptions []ProductOptionValue `json:"product_options,omitempty"`
+}
+
+// OrderProductUpdate is a line of OrderUpdate, only the fields that are set are sent. With an ID it changes that line
+// of the order, without one it is a new line and needs the same fields as an OrderProductWrite
+type OrderProductUpdate struct {
+	ID             int64                `json:"id,omitempty"`
+	ProductID      int64                `json:"product_id,omitempty"`
+	Name           *string              `json:"name,omitempty"`
+	NameCustomer   *string              `json:"name_customer,omitempty"`
+	NameMerchant   *string              `json:"name_merchant,omitempty"`
+	Sku            *string              `json:"sku,omitempty"`
+	Upc            *string              `json:"upc,omitempty"`
+	Quantity       *int64               `json:"quantity,omitempty"`
+	PriceExTax     *primative.Money     `json:"price_ex_tax,omitempty"`
+	PriceIncTax    *primative.Money     `json:"price_inc_tax,omitempty"`
+	ProductOptions []ProductOptionValue `json:"product_options,omitempty"`
+}
+
+// ShippingAddressUpdate is a shipping address of OrderUpdate, only the fields that are set are sent. With an ID it
+// changes that address of the order, without one it is a new address and needs the same fields as an Address
+type ShippingAddressUpdate struct {
+	ID             int64       `json:"id,omitempty"`
+	FirstName      *string     `json:"first_name,omitempty"`
+	LastName       *string     `json:"last_name,omitempty"`
+	Company        *string     `json:"company,omitempty"`
+	Street1        *string     `json:"street_1,omitempty"`
+	Street2        *string     `json:"street_2,omitempty"`
+	City           *string     `json:"city,omitempty"`
+	State          *string     `json:"state,omitempty"`
+	Zip            *string     `json:"zip,omitempty"`
+	Country        *string     `json:"country,omitempty"`
+	CountryIso2    *string     `json:"country_iso2,omitempty"`
+	Phone          *string     `json:"phone,omitempty"`
+	Email          *string     `json:"email,omitempty"`
+	FormFields     []FormField `json:"form_fields,omitempty"`
+	ShippingMethod *string     `json:"shipping_method,omitempty"`
+}
+
+// ProductOptionValue picks the value of one of a catalog product's options when adding it to an order, ID is the
+// product option id and Value the option value id (or the text for text options)
+type ProductOptionValue struct {
+	ID    int64  `json:"id"`
+	Value string `json:"value"`
+}
+
+// Validate checks the fields BigCommerce requires to create an order are filled in
+func (o OrderCreate) Validate() error {
+	if len(o.Products) == 0 {
+		return errors.New("order: at least one product is required")
+	}
+	for i, p := range o.Products {
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("order: products[%d]: %w", i, err)
+		}
+	}
+	if err := o.BillingAddress.validate(); err != nil {
+		return fmt.Errorf("order: billing_address: %w", err)
+	}
+	for i, a := range o.ShippingAddresses {
+		if err := a.validate(); err != nil {
+			return fmt.Errorf("order: shipping_addresses[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the new products and shipping addresses of the patch are complete, the lines and addresses it
+// changes only need what is changing
+func (o OrderUpdate) Validate() error {
+	for i, p := range o.Products {
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("order: products[%d]: %w", i, err)
+		}
+	}
+	for i, a := range o.ShippingAddresses {
+		if err := a.validate(); err != nil {
+			return fmt.Errorf("order: shipping_addresses[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p OrderProductUpdate) validate() error {
+	if p.ID != 0 {
+		// changing an existing line, BigCommerce already knows what it is
+		if p.Quantity != nil && *p.Quantity < 0 {
+			return errors.New("quantity can not be negative")
+		}
+		return nil
+	}
+	line := OrderProductWrite{
+		ProductID:   p.ProductID,
+		PriceExTax:  p.PriceExTax,
+		PriceIncTax: p.PriceIncTax,
+	}
+	if p.Name != nil {
+		line.Name = *p.Name
+	}
+	if p.Quantity != nil {
+		line.Quantity = *p.Quantity
+	}
+	return line.validate()
+}
+
+func (p OrderProductWrite) validate() error {
+	if p.Quantity <= 0 {
+		return errors.New("quantity must be at least 1")
+	}
+	if p.ProductID != 0 {
+		return nil
+	}
+	if p.Name == "" {
+		return errors.New("product_id or name is required")
+	}
+	if p.PriceExTax == nil || p.PriceIncTax == nil {
+		return errors.New("price_ex_tax and price_inc_tax are required for custom products")
+	}
+	return nil
+}
+
+func (a ShippingAddressUpdate) validate() error {
+	if a.ID != 0 {
+		// changing an existing address, whatever is not set stays as it is
+		return nil
+	}
+	value := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	return Address{
+		FirstName:   value(a.FirstName),
+		LastName:    value(a.LastName),
+		Street1:     value(a.Street1),
+		City:        value(a.City),
+		Zip:         value(a.Zip),
+		Country:     value(a.Country),
+		CountryIso2: value(a.CountryIso2),
+	}.validate()
+}
+
+func (a Address) validate() error {
+	switch {
+	case a.FirstName == "":
+		return errors.New("first_name is required")
+	case a.LastName == "":
+		return errors.New("last_name is required")
+	case a.Street1 == "":
+		return errors.New("street_1 is required")
+	case a.City == "":
+		return errors.New("city is required")
+	case a.Zip == "":
+		return errors.New("zip is required")
+	case a.Country == "" && a.CountryIso2 == "":
+		return errors.New("country or country_iso2 is required")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package order
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/dan-collins/biggommerce/primative"
+)
+
+func TestOrderUpdatePatch(t *testing.T) {
+	price := primative.MustParseMoney("12.50")
+	zip := "90210"
+	patch := OrderUpdate{
+		BillingAddress:    &Address{Phone: "555 0100"},
+		ShippingAddresses: []ShippingAddressUpdate{{ID: 7, Zip: &zip}},
+		Products:          []OrderProductUpdate{{ID: 3, PriceExTax: &price, PriceIncTax: &price}},
+	}
+	if err := patch.Validate(); err != nil {
+		t.Fatalf("a partial patch of existing lines and addresses should be valid: %v", err)
+	}
+	b, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "quantity") {
+		t.Errorf("a price only patch sent a quantity: %s", b)
+	}
+	var sent struct {
+		ShippingAddresses []map[string]interface{} `json:"shipping_addresses"`
+	}
+	if err := json.Unmarshal(b, &sent); err != nil {
+		t.Fatal(err)
+	}
+	if len(sent.ShippingAddresses) != 1 || len(sent.ShippingAddresses[0]) != 2 ||
+		sent.ShippingAddresses[0]["id"] != 7.0 || sent.ShippingAddresses[0]["zip"] != zip {
+		t.Errorf("a zip only address patch should send just the id and zip: %s", b)
+	}
+	if strings.Contains(string(b), "shipping_method") {
+		t.Errorf("an untouched shipping method was sent and would be wiped: %s", b)
+	}
+
+	zero := int64(0)
+	patch = OrderUpdate{Products: []OrderProductUpdate{{ID: 3, Quantity: &zero}}}
+	b, _ = json.Marshal(patch)
+	if !strings.Contains(string(b), `"quantity":0`) {
+		t.Errorf("an explicit quantity of 0 was not sent: %s", b)
+	}
+}
+
+func TestOrderUpdateValidateNewLines(t *testing.T) {
+	one := int64(1)
+	name := "Gift wrap"
+	price := primative.MustParseMoney("2")
+	first, last, street, city, zip, country, method := "Ada", "Lovelace", "1 Main St", "Springfield", "90210", "US", "Free Shipping"
+	tests := []struct {
+		name  string
+		patch OrderUpdate
+		ok    bool
+	}{
+		{"new catalog line", OrderUpdate{Products: []OrderProductUpdate{{ProductID: 5, Quantity: &one}}}, true},
+		{"new line without quantity", OrderUpdate{Products: []OrderProductUpdate{{ProductID: 5}}}, false},
+		{"new custom line", OrderUpdate{Products: []OrderProductUpdate{{Name: &name, Quantity: &one, PriceExTax: &price, PriceIncTax: &price}}}, true},
+		{"new custom line without prices", OrderUpdate{Products: []OrderProductUpdate{{Name: &name, Quantity: &one}}}, false},
+		{"new shipping address", OrderUpdate{ShippingAddresses: []ShippingAddressUpdate{{Zip: &zip}}}, false},
+		{"complete new shipping address", OrderUpdate{ShippingAddresses: []ShippingAddressUpdate{{
+			FirstName: &first, LastName: &last, Street1: &street, City: &city, Zip: &zip, CountryIso2: &country,
+		}}}, true},
+		{"changed shipping method", OrderUpdate{ShippingAddresses: []ShippingAddressUpdate{{ID: 7, ShippingMethod: &method}}}, true},
+	}
+	for _, tt := range tests {
+		err := tt.patch.Validate()
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: err = %v", tt.name, err)
+		}
+	}
+}
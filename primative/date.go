@@ -23,3 +23,11 @@ func (bcD *BCDate) UnmarshalJSON(input []byte) error {
 	bcD.Time = newTime
 	return nil
 }
+
+// MarshalJSON will marshal the date in the same RFC1123Z form BigCommerce sends it in, a zero date is ""
+func (bcD BCDate) MarshalJSON() ([]byte, error) {
+	if bcD.IsZero() {
+		return []byte(`""`), nil
+	}
+	return []byte(`"` + bcD.Format(time.RFC1123Z) + `"`), nil
+}
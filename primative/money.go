@@ -0,0 +1,436 @@
+package primative
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an exact decimal amount, BigCommerce sends amounts like "12.3400" and Money keeps every digit of them
+// rather than rounding through a float64. Exchange rates are held in a Money as well.
+//
+// The zero value is 0. Money is immutable, every operation returns a new value. It unmarshals from a json string or
+// number and marshals back the way it came in with the same digits, so the "12.3400" of a V2 order is sent back as
+// "12.3400" and the 12.34 of a V3 product as 12.34. Amounts made in code marshal as numbers, see Quoted. The result of
+// arithmetic on a quoted amount is quoted too
+type Money struct {
+	// coef is the amount without the decimal point, nil means zero
+	coef  *big.Int
+	scale int32
+	// quoted marks an amount that marshals to a json string
+	quoted bool
+}
+
+// RoundingMode picks how Round and Div deal with digits that do not fit
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest value and halves away from zero, 2.345 -> 2.35 and -2.345 -> -2.35
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds to the nearest value and halves to the even neighbour, 2.345 -> 2.34 and 2.355 -> 2.36
+	RoundHalfEven
+	// RoundDown truncates towards zero, 2.349 -> 2.34 and -2.349 -> -2.34
+	RoundDown
+	// RoundUp rounds away from zero, 2.341 -> 2.35 and -2.341 -> -2.35
+	RoundUp
+	// RoundFloor rounds towards negative infinity, 2.349 -> 2.34 and -2.341 -> -2.35
+	RoundFloor
+	// RoundCeiling rounds towards positive infinity, 2.341 -> 2.35 and -2.349 -> -2.34
+	RoundCeiling
+)
+
+var bigTen = big.NewInt(10)
+
+// maxExponent bounds the exponent ParseMoney accepts, no amount needs more and a huge one would have it build a
+// number with that many digits
+const maxExponent = 64
+
+// NewMoney creates the amount units * 10^-scale, NewMoney(1234, 2) is 12.34
+func NewMoney(units int64, scale int32) Money {
+	if scale < 0 {
+		return Money{coef: new(big.Int).Mul(big.NewInt(units), pow10(-scale))}
+	}
+	return Money{coef: big.NewInt(units), scale: scale}
+}
+
+// MoneyFromFloat creates the amount with the shortest decimal representation of f, it is only as exact as f is
+func MoneyFromFloat(f float64) Money {
+	m, err := ParseMoney(strconv.FormatFloat(f, 'f', -1, 64))
+	if err != nil {
+		// FormatFloat only gives something we can not parse for NaN and infinities
+		return Money{}
+	}
+	return m
+}
+
+// ParseMoney parses a decimal string such as "12.3400", "-5", "0.5" or "1.2e3". An empty string is zero
+func ParseMoney(s string) (Money, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return Money{}, nil
+	}
+	var exp int64
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		var err error
+		exp, err = strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Money{}, fmt.Errorf("money: invalid amount %q", s)
+		}
+		if exp > maxExponent || exp < -maxExponent {
+			return Money{}, fmt.Errorf("money: exponent of %q is out of range", s)
+		}
+		str = str[:i]
+	}
+	neg := false
+	switch {
+	case strings.HasPrefix(str, "-"):
+		neg = true
+		str = str[1:]
+	case strings.HasPrefix(str, "+"):
+		str = str[1:]
+	}
+	intPart, fracPart := str, ""
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		intPart, fracPart = str[:i], str[i+1:]
+	}
+	digits := intPart + fracPart
+	if digits == "" || strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+		return Money{}, fmt.Errorf("money: invalid amount %q", s)
+	}
+	coef, _ := new(big.Int).SetString(digits, 10)
+	if neg {
+		coef.Neg(coef)
+	}
+	scale := int64(len(fracPart)) - exp
+	if scale < 0 {
+		coef.Mul(coef, pow10(int32(-scale)))
+		scale = 0
+	}
+	return Money{coef: coef, scale: int32(scale)}, nil
+}
+
+// MustParseMoney is ParseMoney for amounts known to be valid, it panics if s can not be parsed
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Sum adds up all the amounts
+func Sum(amounts ...Money) Money {
+	var total Money
+	for _, m := range amounts {
+		total = total.Add(m)
+	}
+	return total
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+func (m Money) bigCoef() *big.Int {
+	if m.coef == nil {
+		return new(big.Int)
+	}
+	return m.coef
+}
+
+// rescale returns the coefficient of m expressed with scale decimal places, scale must not be less than m.scale
+func (m Money) rescale(scale int32) *big.Int {
+	if scale == m.scale {
+		return m.bigCoef()
+	}
+	return new(big.Int).Mul(m.bigCoef(), pow10(scale-m.scale))
+}
+
+func maxScale(a, b Money) int32 {
+	if a.scale > b.scale {
+		return a.scale
+	}
+	return b.scale
+}
+
+// Scale returns the number of digits after the decimal point m carries
+func (m Money) Scale() int32 {
+	return m.scale
+}
+
+// Add returns m + o
+func (m Money) Add(o Money) Money {
+	scale := maxScale(m, o)
+	return Money{coef: new(big.Int).Add(m.rescale(scale), o.rescale(scale)), scale: scale, quoted: m.quoted || o.quoted}
+}
+
+// Sub returns m - o
+func (m Money) Sub(o Money) Money {
+	scale := maxScale(m, o)
+	return Money{coef: new(big.Int).Sub(m.rescale(scale), o.rescale(scale)), scale: scale, quoted: m.quoted || o.quoted}
+}
+
+// Mul returns m * o exactly, the result carries the decimal places of both
+func (m Money) Mul(o Money) Money {
+	return Money{coef: new(big.Int).Mul(m.bigCoef(), o.bigCoef()), scale: m.scale + o.scale, quoted: m.quoted || o.quoted}
+}
+
+// MulInt returns m * n
+func (m Money) MulInt(n int64) Money {
+	return Money{coef: new(big.Int).Mul(m.bigCoef(), big.NewInt(n)), scale: m.scale, quoted: m.quoted}
+}
+
+// Div returns m / o rounded to places decimal places with mode, a negative places rounds to a whole number like
+// Round does. Like integer division it panics if o is zero
+func (m Money) Div(o Money, places int32, mode RoundingMode) Money {
+	if o.IsZero() {
+		panic("money: division by zero")
+	}
+	if places < 0 {
+		places = 0
+	}
+	// m / o = (M / O) * 10^(o.scale - m.scale), we want R * 10^-places
+	num := new(big.Int).Set(m.bigCoef())
+	den := new(big.Int).Set(o.bigCoef())
+	if exp := places + o.scale - m.scale; exp >= 0 {
+		num.Mul(num, pow10(exp))
+	} else {
+		den.Mul(den, pow10(-exp))
+	}
+	return Money{coef: roundQuo(num, den, mode), scale: places, quoted: m.quoted || o.quoted}
+}
+
+// Neg returns -m
+func (m Money) Neg() Money {
+	return Money{coef: new(big.Int).Neg(m.bigCoef()), scale: m.scale, quoted: m.quoted}
+}
+
+// Abs returns |m|
+func (m Money) Abs() Money {
+	return Money{coef: new(big.Int).Abs(m.bigCoef()), scale: m.scale, quoted: m.quoted}
+}
+
+// Round returns m rounded to places decimal places with mode, amounts that already fit are returned as they are
+func (m Money) Round(places int32, mode RoundingMode) Money {
+	if places < 0 {
+		places = 0
+	}
+	if m.scale <= places {
+		return m
+	}
+	return Money{coef: roundQuo(m.bigCoef(), pow10(m.scale-places), mode), scale: places, quoted: m.quoted}
+}
+
+// roundQuo returns num / den rounded to an integer with mode
+func roundQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	// direction the truncated quotient has to move to get away from zero
+	away := int64(num.Sign() * den.Sign())
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	cmpHalf := half.Cmp(new(big.Int).Abs(den))
+
+	bump := false
+	switch mode {
+	case RoundHalfUp:
+		bump = cmpHalf >= 0
+	case RoundHalfEven:
+		bump = cmpHalf > 0 || cmpHalf == 0 && q.Bit(0) == 1
+	case RoundUp:
+		bump = true
+	case RoundFloor:
+		bump = away < 0
+	case RoundCeiling:
+		bump = away > 0
+	}
+	if bump {
+		q.Add(q, big.NewInt(away))
+	}
+	return q
+}
+
+// Cmp compares m and o and returns -1 if m < o, 0 if they are equal and +1 if m > o. 1.50 and 1.5 are equal
+func (m Money) Cmp(o Money) int {
+	scale := maxScale(m, o)
+	return m.rescale(scale).Cmp(o.rescale(scale))
+}
+
+// Equal reports whether m and o are the same amount
+func (m Money) Equal(o Money) bool {
+	return m.Cmp(o) == 0
+}
+
+// LessThan reports whether m < o
+func (m Money) LessThan(o Money) bool {
+	return m.Cmp(o) < 0
+}
+
+// GreaterThan reports whether m > o
+func (m Money) GreaterThan(o Money) bool {
+	return m.Cmp(o) > 0
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of m
+func (m Money) Sign() int {
+	return m.bigCoef().Sign()
+}
+
+// IsZero reports whether m is 0
+func (m Money) IsZero() bool {
+	return m.Sign() == 0
+}
+
+// Float64 returns the nearest float64 to m, only use it where the rounding does not matter
+func (m Money) Float64() float64 {
+	f, _ := strconv.ParseFloat(m.String(), 64)
+	return f
+}
+
+// String returns m with all its decimal places, "12.3400" stays "12.3400"
+func (m Money) String() string {
+	digits := new(big.Int).Abs(m.bigCoef()).String()
+	var b strings.Builder
+	if m.Sign() < 0 {
+		b.WriteByte('-')
+	}
+	if m.scale <= 0 {
+		b.WriteString(digits)
+		return b.String()
+	}
+	if pad := int(m.scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(m.scale)
+	b.WriteString(digits[:point])
+	b.WriteByte('.')
+	b.WriteString(digits[point:])
+	return b.String()
+}
+
+// StringFixed returns m rounded half up to exactly places decimal places, padding with zeros if needed
+func (m Money) StringFixed(places int32) string {
+	r := m.Round(places, RoundHalfUp)
+	if r.scale < places {
+		r = Money{coef: r.rescale(places), scale: places}
+	}
+	return r.String()
+}
+
+// currencyFormat is how an ISO 4217 currency is written
+type currencyFormat struct {
+	symbol string
+	places int32
+}
+
+var currencyFormats = map[string]currencyFormat{
+	"AUD": {"A$", 2},
+	"BRL": {"R$", 2},
+	"CAD": {"CA$", 2},
+	"CHF": {"CHF ", 2},
+	"CNY": {"CN¥", 2},
+	"DKK": {"kr ", 2},
+	"EUR": {"€", 2},
+	"GBP": {"£", 2},
+	"HKD": {"HK$", 2},
+	"INR": {"₹", 2},
+	"JPY": {"¥", 0},
+	"KRW": {"₩", 0},
+	"KWD": {"KD ", 3},
+	"MXN": {"MX$", 2},
+	"NOK": {"kr ", 2},
+	"NZD": {"NZ$", 2},
+	"SEK": {"kr ", 2},
+	"SGD": {"S$", 2},
+	"USD": {"$", 2},
+	"ZAR": {"R", 2},
+}
+
+// Format writes m for display in the currency with the given ISO 4217 code, rounded half up to the currency's minor
+// units and grouped in thousands, e.g. "$1,234.50", "¥1,235" or "-£3.10". Unknown codes are written as "XYZ 1,234.50"
+func (m Money) Format(currencyCode string) string {
+	code := strings.ToUpper(currencyCode)
+	cf, ok := currencyFormats[code]
+	if !ok {
+		cf = currencyFormat{symbol: code + " ", places: 2}
+	}
+	number := m.FormatNumber(cf.places, ",", ".")
+	if strings.HasPrefix(number, "-") {
+		return "-" + cf.symbol + number[1:]
+	}
+	return cf.symbol + number
+}
+
+// FormatNumber writes m rounded half up to places decimal places with the integer digits grouped in thousands by
+// thousands and decimal as the decimal point, e.g. FormatNumber(2, ".", ",") gives "-1.234,50"
+func (m Money) FormatNumber(places int32, thousands, decimal string) string {
+	fixed := m.StringFixed(places)
+	neg := strings.HasPrefix(fixed, "-")
+	fixed = strings.TrimPrefix(fixed, "-")
+	intPart, fracPart := fixed, ""
+	if i := strings.IndexByte(fixed, '.'); i >= 0 {
+		intPart, fracPart = fixed[:i], fixed[i+1:]
+	}
+
+	var b strings.Builder
+	if neg {
+		b.WriteByte('-')
+	}
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(r)
+	}
+	if fracPart != "" {
+		b.WriteString(decimal)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// Quoted returns m set to marshal as a json string, the way the V2 API sends amounts
+func (m Money) Quoted() Money {
+	m.quoted = true
+	return m
+}
+
+// Unquoted returns m set to marshal as a json number, the way the V3 API sends amounts
+func (m Money) Unquoted() Money {
+	m.quoted = false
+	return m
+}
+
+// IsQuoted reports whether m marshals as a json string
+func (m Money) IsQuoted() bool {
+	return m.quoted
+}
+
+// MarshalJSON writes m with all its decimal places, as a json string when it is quoted and a number otherwise
+func (m Money) MarshalJSON() ([]byte, error) {
+	if m.quoted {
+		return []byte(`"` + m.String() + `"`), nil
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON reads a json string ("12.3400") or number (12.34) and remembers which it was, null and "" leave m
+// as zero
+func (m *Money) UnmarshalJSON(input []byte) error {
+	input = bytes.TrimSpace(input)
+	if bytes.Equal(input, []byte("null")) {
+		return nil
+	}
+	quoted := len(input) > 0 && input[0] == '"'
+	parsed, err := ParseMoney(strings.Trim(string(input), `"`))
+	if err != nil {
+		return err
+	}
+	parsed.quoted = quoted
+	*m = parsed
+	return nil
+}
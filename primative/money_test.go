@@ -0,0 +1,220 @@
+package primative
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"12.3400", "12.3400"},
+		{"-5", "-5"},
+		{"+3.10", "3.10"},
+		{"0.5", "0.5"},
+		{".5", "0.5"},
+		{" 7 ", "7"},
+		{"", "0"},
+		{"1.2e3", "1200"},
+		{"1.25e-1", "0.125"},
+		{"1e64", "1" + strings.Repeat("0", 64)},
+		{"1e-64", "0." + strings.Repeat("0", 63) + "1"},
+	}
+	for _, tt := range tests {
+		m, err := ParseMoney(tt.in)
+		if err != nil {
+			t.Errorf("ParseMoney(%q): %v", tt.in, err)
+			continue
+		}
+		if got := m.String(); got != tt.want {
+			t.Errorf("ParseMoney(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseMoneyInvalid(t *testing.T) {
+	for _, in := range []string{
+		"abc", "1.2.3", "--1", "1e", "e5", "12,34", "1e1.5",
+		// exponents that would have the parser build numbers of billions of digits, or overflow the scale
+		"1e65", "1e-65", "1e999999999", "1e-2147483648", "1e2147483647",
+	} {
+		if m, err := ParseMoney(in); err == nil {
+			t.Errorf("ParseMoney(%q) = %s, want an error", in, m)
+		}
+	}
+}
+
+func TestMoneyJSONRoundTrip(t *testing.T) {
+	type amounts struct {
+		V2   Money `json:"v2"`
+		V3   Money `json:"v3"`
+		Zero Money `json:"zero"`
+	}
+	in := `{"v2":"12.3400","v3":12.34,"zero":"0.0000"}`
+	var a amounts
+	if err := json.Unmarshal([]byte(in), &a); err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != in {
+		t.Errorf("round trip = %s, want %s", out, in)
+	}
+
+	if err := json.Unmarshal([]byte(`{"v2":null,"v3":""}`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if !a.V2.Equal(MustParseMoney("12.34")) || !a.V3.IsZero() {
+		t.Errorf("null should leave the amount alone and \"\" be zero, got %s and %s", a.V2, a.V3)
+	}
+	if err := json.Unmarshal([]byte(`{"v2":"1e999999999"}`), &a); err == nil {
+		t.Error("an amount with a huge exponent should fail to unmarshal")
+	}
+}
+
+func TestMoneyEncoding(t *testing.T) {
+	quoted := MustParseMoney("10.00").Quoted()
+	plain := MustParseMoney("2.5")
+	tests := []struct {
+		name string
+		m    Money
+		want string
+	}{
+		{"made in code", plain, `2.5`},
+		{"quoted", quoted, `"10.00"`},
+		{"quoted plus plain", quoted.Add(plain), `"12.50"`},
+		{"plain minus quoted", plain.Sub(quoted), `"-7.50"`},
+		{"quoted times plain", quoted.Mul(plain), `"25.000"`},
+		{"quoted divided", quoted.Div(NewMoney(3, 0), 2, RoundHalfUp), `"3.33"`},
+		{"quoted rounded", MustParseMoney("1.005").Quoted().Round(2, RoundHalfEven), `"1.00"`},
+		{"sum", Sum(plain, quoted), `"12.50"`},
+		{"unquoted", quoted.Unquoted(), `10.00`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("%s: marshalled %s, want %s", tt.name, b, tt.want)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	m := MustParseMoney
+	tests := []struct {
+		name string
+		got  Money
+		want string
+	}{
+		{"add", m("0.1").Add(m("0.2")), "0.3"},
+		{"add scales", m("1.5").Add(m("0.25")), "1.75"},
+		{"sub", m("10").Sub(m("0.01")), "9.99"},
+		{"mul", m("1.10").Mul(m("3")), "3.30"},
+		{"mul int", m("19.99").MulInt(3), "59.97"},
+		{"div", m("10").Div(m("3"), 2, RoundHalfUp), "3.33"},
+		{"div up", m("2").Div(m("3"), 2, RoundHalfUp), "0.67"},
+		{"div rate", m("100.00").Div(m("0.7500"), 4, RoundHalfEven), "133.3333"},
+		{"div negative places", m("1234").Div(m("10"), -2, RoundHalfUp), "123"},
+		{"neg", m("3.10").Neg(), "-3.10"},
+		{"abs", m("-3.10").Abs(), "3.10"},
+		{"sum", Sum(m("1"), m("2.50"), m("-0.5")), "3.00"},
+		{"round keeps short amounts", m("1.5").Round(2, RoundHalfUp), "1.5"},
+	}
+	for _, tt := range tests {
+		if s := tt.got.String(); s != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, s, tt.want)
+		}
+	}
+	if b, err := json.Marshal(m("1234").Div(m("10"), -2, RoundHalfUp)); err != nil || string(b) != "123" {
+		t.Errorf("Div with negative places marshalled to %s, %v", b, err)
+	}
+	if s := m("1.5").StringFixed(3); s != "1.500" {
+		t.Errorf("StringFixed pads to %s, want 1.500", s)
+	}
+	if !m("1.50").Equal(m("1.5")) || !m("1.49").LessThan(m("1.5")) || !m("-1").LessThan(Money{}) {
+		t.Error("comparisons should ignore trailing zeros and order by value")
+	}
+	if f := m("12.3400").Float64(); f != 12.34 {
+		t.Errorf("Float64 = %v", f)
+	}
+}
+
+func TestMoneyRound(t *testing.T) {
+	tests := []struct {
+		in   string
+		mode RoundingMode
+		want string
+	}{
+		{"2.345", RoundHalfUp, "2.35"},
+		{"-2.345", RoundHalfUp, "-2.35"},
+		{"2.344", RoundHalfUp, "2.34"},
+		{"2.345", RoundHalfEven, "2.34"},
+		{"2.355", RoundHalfEven, "2.36"},
+		{"2.3451", RoundHalfEven, "2.35"},
+		{"2.349", RoundDown, "2.34"},
+		{"-2.349", RoundDown, "-2.34"},
+		{"2.341", RoundUp, "2.35"},
+		{"-2.341", RoundUp, "-2.35"},
+		{"2.349", RoundFloor, "2.34"},
+		{"-2.341", RoundFloor, "-2.35"},
+		{"2.341", RoundCeiling, "2.35"},
+		{"-2.349", RoundCeiling, "-2.34"},
+		{"2.340", RoundUp, "2.34"},
+	}
+	for _, tt := range tests {
+		if got := MustParseMoney(tt.in).Round(2, tt.mode).String(); got != tt.want {
+			t.Errorf("Round(%s, %d) = %s, want %s", tt.in, tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	tests := []struct {
+		amount string
+		code   string
+		want   string
+	}{
+		{"1234.5", "USD", "$1,234.50"},
+		{"1234567.891", "usd", "$1,234,567.89"},
+		{"999.995", "USD", "$1,000.00"},
+		{"0", "USD", "$0.00"},
+		{"1234.5", "JPY", "¥1,235"},
+		{"-3.1", "GBP", "-£3.10"},
+		{"12.3456", "KWD", "KD 12.346"},
+		{"1234.5", "XYZ", "XYZ 1,234.50"},
+		{"100", "EUR", "€100.00"},
+	}
+	for _, tt := range tests {
+		if got := MustParseMoney(tt.amount).Format(tt.code); got != tt.want {
+			t.Errorf("Format(%s, %s) = %q, want %q", tt.amount, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestMoneyFormatNumber(t *testing.T) {
+	tests := []struct {
+		amount    string
+		places    int32
+		thousands string
+		decimal   string
+		want      string
+	}{
+		{"-1234.5", 2, ".", ",", "-1.234,50"},
+		{"1234567", 0, " ", ".", "1 234 567"},
+		{"1234.5678", 3, "", ".", "1234.568"},
+		{"-0.001", 2, ",", ".", "0.00"},
+		{"999", 2, ",", ".", "999.00"},
+	}
+	for _, tt := range tests {
+		if got := MustParseMoney(tt.amount).FormatNumber(tt.places, tt.thousands, tt.decimal); got != tt.want {
+			t.Errorf("FormatNumber(%s) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package primative
 
-import "github.com/dan-collins/biggommerce/connect"
+import (
+	"context"
+
+	"github.com/dan-collins/biggommerce/connect"
+)
 
 // Resource is general struct for resource url and type found in many returned objects from bigcommerce
 type Resource struct {
@@ -10,6 +14,14 @@ type Resource struct {
 
 // EagerGet - attempts to unmarshal a resource url into an interface, preferably one intended to unmarshal the json body of that url.
 func (r Resource) EagerGet(s connect.Client, i interface{}) error {
+	return r.EagerGetContext(context.Background(), s, i)
+}
+
+// EagerGetContext - same as EagerGet but the request is cancelled when ctx is done, provided s is a connect.APIClient
+func (r Resource) EagerGetContext(ctx context.Context, s connect.Client, i interface{}) error {
 	url := r.URL
+	if c, ok := s.(connect.APIClient); ok {
+		return c.GetAndUnmarshalRawContext(ctx, url, &i)
+	}
 	return s.GetAndUnmarshalRaw(url, &i)
 }
@@ -0,0 +1,363 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dan-collins/biggommerce/connect"
+	"github.com/google/go-querystring/query"
+)
+
+// Client is a wrapper struct that embeds the BCClient from the client package. It manages the webhooks of the store
+// through the V3 hooks API, which only ever shows the hooks created with the client's own credentials
+type Client struct {
+	connect.BCClient
+}
+
+// NewClient will create a new webhook client wrapper based on BC connection details
+func NewClient(authToken, authClient, storeKey string) *Client {
+	bcClient := connect.NewClient(authToken, authClient, storeKey)
+	hookClient := Client{}
+	hookClient.BCClient = *bcClient
+	return &hookClient
+}
+
+// Hook is a webhook subscription, deliveries for Scope are POSTed to Destination with Headers added
+type Hook struct {
+	ID          int64  `json:"id,omitempty"`
+	ClientID    string `json:"client_id,omitempty"`
+	StoreHash   string `json:"store_hash,omitempty"`
+	Scope       Scope  `json:"scope"`
+	Destination string `json:"destination"`
+	// IsActive is always set on the hooks BigCommerce returns. When writing, nil leaves it to BigCommerce on create
+	// and as it is on update, EnsureHooks takes nil to mean active
+	IsActive             *bool `json:"is_active,omitempty"`
+	EventsHistoryEnabled bool  `json:"events_history_enabled"`
+	// Headers are added to every delivery. When writing, nil leaves them as they are on update while an empty map
+	// removes them, EnsureHooks only compares the headers of desired hooks that have them set
+	Headers   map[string]string `json:"headers,omitempty"`
+	CreatedAt int64             `json:"created_at,omitempty"`
+	UpdatedAt int64             `json:"updated_at,omitempty"`
+}
+
+// hookWrite is the part of a Hook that can be created or updated
+type hookWrite struct {
+	Scope                Scope  `json:"scope"`
+	Destination          string `json:"destination"`
+	IsActive             *bool  `json:"is_active,omitempty"`
+	EventsHistoryEnabled bool   `json:"events_history_enabled"`
+	// Headers is a pointer so that an empty map is sent as {} to remove the headers, while nil is left out
+	Headers *map[string]string `json:"headers,omitempty"`
+}
+
+func (h Hook) write() hookWrite {
+	w := hookWrite{
+		Scope:                h.Scope,
+		Destination:          h.Destination,
+		IsActive:             h.IsActive,
+		EventsHistoryEnabled: h.EventsHistoryEnabled,
+	}
+	if h.Headers != nil {
+		w.Headers = &h.Headers
+	}
+	return w
+}
+
+// HookQuery filters the hooks GetHooks returns
+type HookQuery struct {
+	Scope       Scope  `url:"scope,omitempty"`
+	Destination string `url:"destination,omitempty"`
+	IsActive    *bool  `url:"is_active,omitempty"`
+	Page        int    `url:"page,omitempty"`
+	Limit       int    `url:"limit,omitempty"`
+}
+
+// GetRawQuery gets the struct in query string form
+func (q HookQuery) GetRawQuery() (string, error) {
+	v, err := query.Values(q)
+	if err != nil {
+		return "", err
+	}
+	return v.Encode(), nil
+}
+
+// EventsQuery pages through the events history of GetEvents
+type EventsQuery struct {
+	Page  int `url:"page,omitempty"`
+	Limit int `url:"limit,omitempty"`
+}
+
+// GetRawQuery gets the struct in query string form
+func (q EventsQuery) GetRawQuery() (string, error) {
+	v, err := query.Values(q)
+	if err != nil {
+		return "", err
+	}
+	return v.Encode(), nil
+}
+
+// AdminStatus is the state of webhooks across the store, the hooks BigCommerce deactivated and the destinations it
+// stopped delivering to after too many failures
+type AdminStatus struct {
+	Emails         []string        `json:"emails"`
+	HooksList      []Hook          `json:"hooks_list"`
+	BlockedDomains []BlockedDomain `json:"blocked_domains"`
+}
+
+// BlockedDomain is a destination deliveries are no longer sent to
+type BlockedDomain struct {
+	Destination string        `json:"destination"`
+	Reasons     []BlockReason `json:"reasons"`
+}
+
+// BlockReason is a failure that got a domain blocked
+type BlockReason struct {
+	FailureDescription string `json:"failure_description"`
+	TimeStamp          int64  `json:"time_stamp"`
+}
+
+// AdminUpdate sets the addresses BigCommerce emails when a hook is deactivated or a domain blocked
+type AdminUpdate struct {
+	Emails []string `json:"emails"`
+}
+
+// GetHooks will return the hooks matching hq, every page is read unless hq.Page is set
+func (s *Client) GetHooks(hq HookQuery) ([]Hook, error) {
+	return s.GetHooksContext(context.Background(), hq)
+}
+
+// GetHooksContext - same as GetHooks, cancelling ctx stops fetching any further pages
+func (s *Client) GetHooksContext(ctx context.Context, hq HookQuery) ([]Hook, error) {
+	rawQuery, err := hq.GetRawQuery()
+	if err != nil {
+		return nil, err
+	}
+	data := make([]Hook, 0)
+	if hq.Page != 0 {
+		_, err = s.GetV3AndUnmarshalContext(ctx, "v3/hooks", rawQuery, &data)
+	} else {
+		err = s.GetAllPagesContext(ctx, "v3/hooks", rawQuery, &data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetHook will return a single hook
+func (s *Client) GetHook(hookID int64) (*Hook, error) {
+	return s.GetHookContext(context.Background(), hookID)
+}
+
+// GetHookContext - same as GetHook but the request is cancelled when ctx is done
+func (s *Client) GetHookContext(ctx context.Context, hookID int64) (*Hook, error) {
+	var data Hook
+	_, err := s.GetV3AndUnmarshalContext(ctx, fmt.Sprintf("v3/hooks/%d", hookID), "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// CreateHook will subscribe to h.Scope, the ID and timestamps of h are ignored
+func (s *Client) CreateHook(h Hook) (*Hook, error) {
+	return s.CreateHookContext(context.Background(), h)
+}
+
+// CreateHookContext - same as CreateHook but the request is cancelled when ctx is done
+func (s *Client) CreateHookContext(ctx context.Context, h Hook) (*Hook, error) {
+	var data Hook
+	_, err := s.DoV3Context(ctx, "POST", "v3/hooks", "", h.write(), &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateHook will replace the scope, destination, headers and flags of the hook with those of h
+func (s *Client) UpdateHook(hookID int64, h Hook) (*Hook, error) {
+	return s.UpdateHookContext(context.Background(), hookID, h)
+}
+
+// UpdateHookContext - same as UpdateHook but the request is cancelled when ctx is done
+func (s *Client) UpdateHookContext(ctx context.Context, hookID int64, h Hook) (*Hook, error) {
+	var data Hook
+	_, err := s.DoV3Context(ctx, "PUT", fmt.Sprintf("v3/hooks/%d", hookID), "", h.write(), &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteHook will delete the hook, nothing more is delivered for it
+func (s *Client) DeleteHook(hookID int64) error {
+	return s.DeleteHookContext(context.Background(), hookID)
+}
+
+// DeleteHookContext - same as DeleteHook but the request is cancelled when ctx is done
+func (s *Client) DeleteHookContext(ctx context.Context, hookID int64) error {
+	_, err := s.DoV3Context(ctx, "DELETE", fmt.Sprintf("v3/hooks/%d", hookID), "", nil, nil)
+	return err
+}
+
+// GetEvents will return the events sent to hooks with EventsHistoryEnabled, every page is read unless eq.Page is set
+func (s *Client) GetEvents(eq EventsQuery) ([]Event, error) {
+	return s.GetEventsContext(context.Background(), eq)
+}
+
+// GetEventsContext - same as GetEvents, cancelling ctx stops fetching any further pages
+func (s *Client) GetEventsContext(ctx context.Context, eq EventsQuery) ([]Event, error) {
+	rawQuery, err := eq.GetRawQuery()
+	if err != nil {
+		return nil, err
+	}
+	data := make([]Event, 0)
+	if eq.Page != 0 {
+		_, err = s.GetV3AndUnmarshalContext(ctx, "v3/hooks/events", rawQuery, &data)
+	} else {
+		err = s.GetAllPagesContext(ctx, "v3/hooks/events", rawQuery, &data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetAdminStatus will return the notification emails, deactivated hooks and blocked domains of the store
+func (s *Client) GetAdminStatus() (*AdminStatus, error) {
+	return s.GetAdminStatusContext(context.Background())
+}
+
+// GetAdminStatusContext - same as GetAdminStatus but the request is cancelled when ctx is done
+func (s *Client) GetAdminStatusContext(ctx context.Context) (*AdminStatus, error) {
+	var data AdminStatus
+	_, err := s.GetV3AndUnmarshalContext(ctx, "v3/hooks/admin", "", &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// UpdateAdmin will set the addresses notified about deactivated hooks and blocked domains
+func (s *Client) UpdateAdmin(au AdminUpdate) error {
+	return s.UpdateAdminContext(context.Background(), au)
+}
+
+// UpdateAdminContext - same as UpdateAdmin but the request is cancelled when ctx is done
+func (s *Client) UpdateAdminContext(ctx context.Context, au AdminUpdate) error {
+	_, err := s.DoV3Context(ctx, "PUT", "v3/hooks/admin", "", au, nil)
+	return err
+}
+
+// EnsureHooks will make the store's hooks match desired and return them. Hooks are matched on scope and destination:
+// a desired hook that is missing is created, one whose flags or headers differ is updated, and any other hook,
+// duplicates included, is deleted. A desired hook with a nil IsActive is made active, one with nil Headers keeps
+// whatever headers the store's hook has, set Headers to an empty map to remove them. Running it again with the same
+// desired hooks changes nothing.
+//
+// WARNING: every hook not in desired is DELETED. BigCommerce scopes hooks to the API account, not to an app or an
+// environment, so this includes hooks created by other deployments, staging and production for example, or other
+// services that share the same credentials. Only use EnsureHooks when desired is the complete list of hooks for
+// those credentials, otherwise manage hooks one at a time with CreateHook, UpdateHook and DeleteHook
+func (s *Client) EnsureHooks(desired []Hook) ([]Hook, error) {
+	return s.EnsureHooksContext(context.Background(), desired)
+}
+
+// EnsureHooksContext - same as EnsureHooks, cancelling ctx stops before the next change is made
+func (s *Client) EnsureHooksContext(ctx context.Context, desired []Hook) ([]Hook, error) {
+	existing, err := s.GetHooksContext(ctx, HookQuery{})
+	if err != nil {
+		return nil, err
+	}
+	current := map[hookKey]Hook{}
+	var extra []Hook
+	for _, h := range existing {
+		key := keyOf(h)
+		if _, dup := current[key]; dup {
+			extra = append(extra, h)
+			continue
+		}
+		current[key] = h
+	}
+
+	wanted := map[hookKey]bool{}
+	result := make([]Hook, 0, len(desired))
+	for _, want := range desired {
+		key := keyOf(want)
+		if wanted[key] {
+			return nil, fmt.Errorf("webhook: %s to %s is desired more than once", want.Scope, want.Destination)
+		}
+		wanted[key] = true
+		if want.IsActive == nil {
+			active := true
+			want.IsActive = &active
+		}
+		have, ok := current[key]
+		switch {
+		case !ok:
+			created, err := s.CreateHookContext(ctx, want)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, *created)
+		case !sameHook(have, want):
+			updated, err := s.UpdateHookContext(ctx, have.ID, want)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, *updated)
+		default:
+			result = append(result, have)
+		}
+	}
+
+	for key, h := range current {
+		if !wanted[key] {
+			extra = append(extra, h)
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i].ID < extra[j].ID })
+	for _, h := range extra {
+		err = s.DeleteHookContext(ctx, h.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// hookKey is what EnsureHooks matches hooks on
+type hookKey struct {
+	scope       Scope
+	destination string
+}
+
+func keyOf(h Hook) hookKey {
+	return hookKey{scope: h.Scope, destination: h.Destination}
+}
+
+// sameHook reports whether the store's hook have already is what want would write, the headers are only compared
+// when want has them set
+func sameHook(have, want Hook) bool {
+	if isActive(have) != isActive(want) || have.EventsHistoryEnabled != want.EventsHistoryEnabled {
+		return false
+	}
+	if want.Headers == nil {
+		return true
+	}
+	if len(have.Headers) != len(want.Headers) {
+		return false
+	}
+	for name, value := range want.Headers {
+		if other, ok := have.Headers[name]; !ok || other != value {
+			return false
+		}
+	}
+	return true
+}
+
+// isActive reads IsActive, hooks read back from BigCommerce always have it and EnsureHooks sets it on desired ones
+func isActive(h Hook) bool {
+	return h.IsActive != nil && *h.IsActive
+}
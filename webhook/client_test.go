@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestEnsureHooks(t *testing.T) {
+	existing := `[
+		{"id":1,"scope":"store/order/created","destination":"https://example.com/hooks","is_active":true,"events_history_enabled":false,"headers":{"X-Webhook-Secret":"s3cret"}},
+		{"id":2,"scope":"store/order/updated","destination":"https://example.com/hooks","is_active":false,"events_history_enabled":false},
+		{"id":3,"scope":"store/product/updated","destination":"https://staging.example.com/hooks","is_active":true,"events_history_enabled":false},
+		{"id":4,"scope":"store/order/statusUpdated","destination":"https://example.com/hooks","is_active":true,"events_history_enabled":false,"headers":{"X-Webhook-Secret":"s3cret"}}
+	]`
+	var mu sync.Mutex
+	var writes []string
+	bodies := map[string]map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"data":` + existing + `,"meta":{"pagination":{"total":4,"count":4,"current_page":1,"total_pages":1}}}`))
+			return
+		}
+		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		call := r.Method + " " + id
+		body, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		writes = append(writes, call)
+		if len(body) > 0 {
+			var fields map[string]interface{}
+			json.Unmarshal(body, &fields)
+			bodies[call] = fields
+		}
+		mu.Unlock()
+		if r.Method == "DELETE" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"data":` + string(body) + `}`))
+	}))
+	defer srv.Close()
+	c := NewClient("token", "client", "store")
+	c.SetBaseURL(srv.URL + "/")
+	c.Limiter = nil
+
+	inactive := false
+	hooks, err := c.EnsureHooks([]Hook{
+		{Scope: ScopeOrderCreated, Destination: "https://example.com/hooks"},
+		{Scope: ScopeOrderUpdated, Destination: "https://example.com/hooks"},
+		{Scope: ScopeOrderArchived, Destination: "https://example.com/hooks", IsActive: &inactive},
+		{Scope: ScopeOrderStatusUpdated, Destination: "https://example.com/hooks", Headers: map[string]string{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks) != 4 {
+		t.Fatalf("got %d hooks, want 4", len(hooks))
+	}
+
+	// hook 1 already matches as its headers were left alone, hook 2 was deactivated, the archived hook is new, hook 4
+	// has headers to remove and hook 3 is not desired
+	want := []string{"PUT 2", "POST hooks", "PUT 4", "DELETE 3"}
+	if strings.Join(writes, ",") != strings.Join(want, ",") {
+		t.Fatalf("EnsureHooks sent %v, want %v", writes, want)
+	}
+	if active, ok := bodies["PUT 2"]["is_active"]; !ok || active != true {
+		t.Errorf("a desired hook without IsActive was written with is_active %v, want true", active)
+	}
+	if active, ok := bodies["POST hooks"]["is_active"]; !ok || active != false {
+		t.Errorf("a hook asked to be inactive was created with is_active %v, want false", active)
+	}
+	if _, ok := bodies["PUT 2"]["headers"]; ok {
+		t.Error("a desired hook without headers should leave the headers alone")
+	}
+	if headers, ok := bodies["PUT 4"]["headers"].(map[string]interface{}); !ok || len(headers) != 0 {
+		t.Errorf("removing the headers sent %v, want an empty object", bodies["PUT 4"]["headers"])
+	}
+}
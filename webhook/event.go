@@ -0,0 +1,151 @@
+// Package webhook receives BigCommerce webhook deliveries. BigCommerce only sends the scope and the id of what
+// changed, Handler parses that into an Event, drops retries of deliveries it already handled and can fetch the order an
+// event is about before handing it on.
+//
+//	h, err := webhook.NewHandler(map[string]string{"X-Webhook-Secret": secret})
+//	if err != nil {
+//		return err
+//	}
+//	h.Orders = order.NewClient(authToken, authClient, storeKey)
+//	h.On(webhook.ScopeOrderStatusUpdated, func(ctx context.Context, e webhook.Event) error {
+//		return ship(e.Order)
+//	})
+//	http.Handle("/webhooks", h)
+//
+// Client manages the hooks themselves, see EnsureHooks.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/dan-collins/biggommerce/order"
+)
+
+// Event is a single webhook delivery
+type Event struct {
+	Scope    Scope  `json:"scope"`
+	StoreID  string `json:"store_id"`
+	Producer string `json:"producer"`
+	// Hash is sent by BigCommerce and is the same for every retry of a delivery, it is not verified against Data
+	Hash string `json:"hash"`
+	// CreatedAt is the unix time the event happened, see Created
+	CreatedAt int64 `json:"created_at"`
+	Data      Data  `json:"data"`
+	// Order is the order the event is about, only set when the Handler hydrates orders and the order still exists
+	Order *order.Order `json:"-"`
+}
+
+// Created is CreatedAt as a time
+func (e Event) Created() time.Time {
+	return time.Unix(e.CreatedAt, 0)
+}
+
+// OrderID is the id of the order an order event is about, or the order of a shipment or converted cart
+func (e Event) OrderID() (int64, bool) {
+	if e.Data.Type == "order" {
+		id, err := e.Data.ID.Int64()
+		return id, err == nil
+	}
+	return e.Data.OrderID, e.Data.OrderID != 0
+}
+
+// Data is what changed, the fields besides Type and ID are only sent for the scopes noted on them
+type Data struct {
+	// Type is the kind of resource, e.g. order, shipment, product, customer or cart
+	Type string `json:"type"`
+	ID   ID     `json:"id"`
+	// OrderID is the order of a shipment event or of store/cart/converted
+	OrderID int64 `json:"orderId,omitempty"`
+	// CartID is the cart of a store/cart/lineItem event
+	CartID string `json:"cartId,omitempty"`
+	// Status is sent with store/order/statusUpdated
+	Status *StatusChange `json:"status,omitempty"`
+	// Message is sent with store/order/message/created
+	Message *MessageRef `json:"message,omitempty"`
+	// Refund is sent with store/order/refund/created
+	Refund *RefundRef `json:"refund,omitempty"`
+	// SKU is sent with the store/sku events
+	SKU *SKURef `json:"sku,omitempty"`
+	// Inventory is sent with the inventory events of products and skus
+	Inventory *InventoryChange `json:"inventory,omitempty"`
+	// Address is sent with the store/customer/address events
+	Address *AddressRef `json:"address,omitempty"`
+}
+
+// StatusChange is the status an order moved from and to
+type StatusChange struct {
+	PreviousStatusID int64 `json:"previous_status_id"`
+	NewStatusID      int64 `json:"new_status_id"`
+}
+
+// MessageRef points at the order message that was created
+type MessageRef struct {
+	OrderMessageID int64 `json:"order_message_id"`
+}
+
+// RefundRef points at the refund that was created
+type RefundRef struct {
+	RefundID int64 `json:"refund_id"`
+}
+
+// SKURef is the product and variant a sku belongs to
+type SKURef struct {
+	ProductID int64 `json:"product_id"`
+	VariantID int64 `json:"variant_id"`
+}
+
+// InventoryChange is how the stock level of a product or variant changed, Method is absolute or relative to Value
+type InventoryChange struct {
+	ProductID int64  `json:"product_id"`
+	VariantID int64  `json:"variant_id,omitempty"`
+	Method    string `json:"method"`
+	Value     int64  `json:"value"`
+}
+
+// AddressRef is the customer an address belongs to
+type AddressRef struct {
+	CustomerID int64 `json:"customer_id"`
+}
+
+// ID is the id of what an event is about, a number for most resources but a uuid for carts
+type ID string
+
+// UnmarshalJSON accepts the id as a number or a string
+func (id *ID) UnmarshalJSON(b []byte) error {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		err := json.Unmarshal(b, &s)
+		if err != nil {
+			return err
+		}
+		*id = ID(s)
+		return nil
+	}
+	if bytes.Equal(b, []byte("null")) {
+		*id = ""
+		return nil
+	}
+	var n json.Number
+	err := json.Unmarshal(b, &n)
+	if err != nil {
+		return err
+	}
+	*id = ID(n)
+	return nil
+}
+
+// MarshalJSON writes numeric ids as numbers and anything else as a string
+func (id ID) MarshalJSON() ([]byte, error) {
+	if _, err := id.Int64(); err == nil {
+		return []byte(id), nil
+	}
+	return json.Marshal(string(id))
+}
+
+// Int64 is the id as a number, an error for a uuid
+func (id ID) Int64() (int64, error) {
+	return strconv.ParseInt(string(id), 10, 64)
+}
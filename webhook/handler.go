@@ -0,0 +1,208 @@
+package webhook
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dan-collins/biggommerce/connect"
+	"github.com/dan-collins/biggommerce/order"
+)
+
+// DefaultDedupeWindow is how long a Handler remembers deliveries when DedupeWindow is not set, BigCommerce keeps
+// retrying a failed delivery for up to two days
+const DefaultDedupeWindow = 48 * time.Hour
+
+// maxPayload is the largest body a Handler reads, deliveries are a few hundred bytes
+const maxPayload = 1 << 20
+
+// HandlerFunc handles an event, returning an error answers the delivery with a 500 so BigCommerce retries it
+type HandlerFunc func(ctx context.Context, e Event) error
+
+// Handler is an http.Handler for the destination url of webhooks. A delivery is
+//   - rejected with a 401 unless it carries every one of Headers, the custom headers the webhook was created with.
+//     Without Headers every delivery is rejected, unless Unauthenticated is set
+//   - parsed into an Event, a 400 when that fails
+//   - answered with a 200 straight away when it is a retry of a delivery already handled, going by the hash and
+//     created_at BigCommerce sent. The hash is only used to recognise retries, it is not checked against the data
+//   - hydrated with its Order when Orders is set and the event is about an order, see Event.OrderID
+//   - handed to every HandlerFunc registered for its scope, in the order they were registered
+//
+// A delivery that fails to hydrate or that a HandlerFunc fails on gets a 500 and is not remembered, so the retry is
+// handled again. BigCommerce expects an answer within seconds, handlers that take longer should queue the work.
+// Handler is safe for concurrent use
+type Handler struct {
+	// Headers are the custom headers the webhook was created with, the values are compared in constant time
+	Headers map[string]string
+	// Unauthenticated accepts deliveries when Headers is empty. Anyone who knows the url can then post events, only
+	// set it when something in front of the Handler checks where deliveries come from
+	Unauthenticated bool
+	// Orders fetches the order an event is about through GetHydratedOrderByID when set
+	Orders *order.Client
+	// DedupeWindow is how long a handled delivery is remembered, DefaultDedupeWindow when 0
+	DedupeWindow time.Duration
+
+	mu        sync.Mutex
+	routes    []route
+	seen      map[string]time.Time
+	lastPrune time.Time
+}
+
+type route struct {
+	scope Scope
+	fn    HandlerFunc
+}
+
+// NewHandler returns a Handler that accepts deliveries carrying headers, at least one header with a value is required
+// so that not every post to the url is taken for a delivery
+func NewHandler(headers map[string]string) (*Handler, error) {
+	if len(headers) == 0 {
+		return nil, errors.New("webhook: a handler needs at least one header to check deliveries against")
+	}
+	for name, value := range headers {
+		if value == "" {
+			return nil, fmt.Errorf("webhook: header %s has no value", name)
+		}
+	}
+	return &Handler{Headers: headers}, nil
+}
+
+// On registers fn for the events scope matches, e.g. ScopeOrderStatusUpdated or ScopeOrderAll
+func (h *Handler) On(scope Scope, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.routes = append(h.routes, route{scope: scope, fn: fn})
+}
+
+// ServeHTTP handles a single delivery
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.verify(r.Header) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxPayload))
+	if err != nil {
+		http.Error(w, "unreadable payload", http.StatusBadRequest)
+		return
+	}
+	var e Event
+	err = json.Unmarshal(body, &e)
+	if err != nil || e.Scope == "" {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	key := dedupeKey(e, body)
+	if !h.claim(key) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	err = h.handle(r.Context(), &e)
+	if err != nil {
+		h.release(key)
+		http.Error(w, "webhook handler failed", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// verify checks every configured header is on the delivery with the configured value, with no headers configured
+// only an Unauthenticated handler accepts the delivery
+func (h *Handler) verify(header http.Header) bool {
+	if len(h.Headers) == 0 {
+		return h.Unauthenticated
+	}
+	ok := true
+	for name, want := range h.Headers {
+		got := header.Get(name)
+		if got == "" || subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			ok = false
+		}
+	}
+	return ok
+}
+
+// handle hydrates e if it should be and runs the handlers for its scope
+func (h *Handler) handle(ctx context.Context, e *Event) error {
+	if id, ok := e.OrderID(); ok && h.Orders != nil {
+		o, err := h.Orders.GetHydratedOrderByIDContext(ctx, strconv.FormatInt(id, 10))
+		switch {
+		case err == nil:
+			e.Order = &o
+		case !connect.IsNotFound(err):
+			return err
+		}
+	}
+	h.mu.Lock()
+	routes := append([]route(nil), h.routes...)
+	h.mu.Unlock()
+	for _, rt := range routes {
+		if !rt.scope.Matches(e.Scope) {
+			continue
+		}
+		err := rt.fn(ctx, *e)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// claim remembers key and reports whether it was new, a delivery is claimed while it is being handled so a retry
+// arriving at the same time is dropped too
+func (h *Handler) claim(key string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := time.Now()
+	window := h.DedupeWindow
+	if window <= 0 {
+		window = DefaultDedupeWindow
+	}
+	if h.seen == nil {
+		h.seen = map[string]time.Time{}
+	}
+	if now.Sub(h.lastPrune) > time.Minute {
+		for k, at := range h.seen {
+			if now.Sub(at) > window {
+				delete(h.seen, k)
+			}
+		}
+		h.lastPrune = now
+	}
+	if at, ok := h.seen[key]; ok && now.Sub(at) <= window {
+		return false
+	}
+	h.seen[key] = now
+	return true
+}
+
+// release forgets key so the next retry of the delivery is handled
+func (h *Handler) release(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, key)
+}
+
+// dedupeKey identifies a delivery across retries. The hash BigCommerce sends is the same every time an order is
+// updated, so created_at is part of the key as well. Payloads without a hash are keyed on their sha1
+func dedupeKey(e Event, body []byte) string {
+	if e.Hash == "" {
+		sum := sha1.Sum(body)
+		return hex.EncodeToString(sum[:])
+	}
+	return e.Hash + ":" + strconv.FormatInt(e.CreatedAt, 10)
+}
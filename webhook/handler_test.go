@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dan-collins/biggommerce/bctest"
+	"github.com/dan-collins/biggommerce/order"
+)
+
+var secret = map[string]string{"X-Webhook-Secret": "s3cret"}
+
+func orderEvent(scope string, id, createdAt string) string {
+	return `{"scope":"` + scope + `","store_id":"1001","hash":"abc","created_at":` + createdAt +
+		`,"data":{"type":"order","id":` + id + `}}`
+}
+
+func deliver(h http.Handler, body string, header map[string]string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w.Code
+}
+
+func newTestHandler(t *testing.T) *Handler {
+	t.Helper()
+	h, err := NewHandler(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestNewHandlerNeedsHeaders(t *testing.T) {
+	for _, headers := range []map[string]string{nil, {}, {"X-Webhook-Secret": ""}} {
+		if _, err := NewHandler(headers); err == nil {
+			t.Errorf("NewHandler(%v) should fail", headers)
+		}
+	}
+}
+
+func TestHandlerVerify(t *testing.T) {
+	h := newTestHandler(t)
+	called := 0
+	h.On(ScopeOrderAll, func(ctx context.Context, e Event) error {
+		called++
+		return nil
+	})
+	body := orderEvent("store/order/created", "101", "1700000000")
+
+	for _, header := range []map[string]string{nil, {"X-Webhook-Secret": "wrong"}, {"X-Other": "s3cret"}} {
+		if code := deliver(h, body, header); code != http.StatusUnauthorized {
+			t.Errorf("delivery with headers %v got %d, want 401", header, code)
+		}
+	}
+	if code := deliver(h, body, secret); code != http.StatusOK {
+		t.Errorf("delivery with the secret got %d, want 200", code)
+	}
+	if called != 1 {
+		t.Errorf("handler ran %d times, want only for the delivery with the secret", called)
+	}
+
+	open := &Handler{}
+	if code := deliver(open, body, nil); code != http.StatusUnauthorized {
+		t.Errorf("a handler without headers answered %d, want it to reject every delivery", code)
+	}
+	open.Unauthenticated = true
+	if code := deliver(open, body, nil); code != http.StatusOK {
+		t.Errorf("an Unauthenticated handler answered %d, want 200", code)
+	}
+}
+
+func TestHandlerDedupe(t *testing.T) {
+	h := newTestHandler(t)
+	called := 0
+	h.On(ScopeOrderUpdated, func(ctx context.Context, e Event) error {
+		called++
+		return nil
+	})
+
+	first := orderEvent("store/order/updated", "101", "1700000000")
+	for i := 0; i < 3; i++ {
+		if code := deliver(h, first, secret); code != http.StatusOK {
+			t.Fatalf("delivery %d got %d, want 200", i, code)
+		}
+	}
+	if called != 1 {
+		t.Errorf("handler ran %d times for one delivery and its retries, want 1", called)
+	}
+	// the same order updated again has the same hash but happened later
+	if deliver(h, orderEvent("store/order/updated", "101", "1700000060"), secret); called != 2 {
+		t.Errorf("handler ran %d times, want a later update with the same hash handled", called)
+	}
+}
+
+func TestHandlerRetriesAfterFailure(t *testing.T) {
+	h := newTestHandler(t)
+	calls := 0
+	h.On(ScopeOrderCreated, func(ctx context.Context, e Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database down")
+		}
+		return nil
+	})
+	body := orderEvent("store/order/created", "101", "1700000000")
+
+	if code := deliver(h, body, secret); code != http.StatusInternalServerError {
+		t.Errorf("failed delivery got %d, want 500 so BigCommerce retries", code)
+	}
+	if code := deliver(h, body, secret); code != http.StatusOK || calls != 2 {
+		t.Errorf("retry got %d after %d calls, want it handled again", code, calls)
+	}
+	if deliver(h, body, secret); calls != 2 {
+		t.Error("a retry after the delivery was handled should be dropped")
+	}
+}
+
+func TestHandlerDispatch(t *testing.T) {
+	h := newTestHandler(t)
+	var ran []string
+	register := func(name string, scope Scope) {
+		h.On(scope, func(ctx context.Context, e Event) error {
+			ran = append(ran, name)
+			return nil
+		})
+	}
+	register("all orders", ScopeOrderAll)
+	register("status", ScopeOrderStatusUpdated)
+	register("products", Scope("store/product/*"))
+	register("everything", Scope("store/*"))
+
+	deliver(h, orderEvent("store/order/statusUpdated", "101", "1"), secret)
+	if strings.Join(ran, ",") != "all orders,status,everything" {
+		t.Errorf("a status update ran %q, want the matching handlers in the order they were registered", ran)
+	}
+	ran = nil
+	deliver(h, `{"scope":"store/product/updated","hash":"p","created_at":2,"data":{"type":"product","id":7}}`, secret)
+	if strings.Join(ran, ",") != "products,everything" {
+		t.Errorf("a product update ran %q", ran)
+	}
+	if code := deliver(h, `{"data":{}}`, secret); code != http.StatusBadRequest {
+		t.Errorf("a payload without a scope got %d, want 400", code)
+	}
+}
+
+func TestHandlerHydratesOrders(t *testing.T) {
+	srv := bctest.NewServer()
+	defer srv.Close()
+	id := srv.AddOrder(order.Order{StatusID: 11, Products: []order.OrderProduct{{ID: 1, Quantity: 2}}})
+
+	h := newTestHandler(t)
+	h.Orders = srv.Client()
+	var got []*order.Order
+	h.On(ScopeOrderAll, func(ctx context.Context, e Event) error {
+		got = append(got, e.Order)
+		return nil
+	})
+
+	if code := deliver(h, orderEvent("store/order/created", "101", "1"), secret); code != http.StatusOK {
+		t.Fatalf("delivery got %d, want 200", code)
+	}
+	if len(got) != 1 || got[0] == nil || got[0].ID != id || len(got[0].Products) != 1 {
+		t.Fatalf("handler got %+v, want order %d with its products", got, id)
+	}
+
+	// a deleted order is handed on without one
+	if deliver(h, orderEvent("store/order/archived", "999", "2"), secret); len(got) != 2 || got[1] != nil {
+		t.Error("an event about an order that no longer exists should be handled without the order")
+	}
+
+	// other failures are retried without running the handlers
+	srv.InjectFault(bctest.Fault{Path: "/v2/orders/101", Status: http.StatusForbidden, Times: 1})
+	body := orderEvent("store/order/updated", "101", "3")
+	if code := deliver(h, body, secret); code != http.StatusInternalServerError || len(got) != 2 {
+		t.Errorf("failed hydration got %d with %d handled, want a 500 before the handlers run", code, len(got))
+	}
+	if code := deliver(h, body, secret); code != http.StatusOK || len(got) != 3 {
+		t.Errorf("retry after failed hydration got %d, want it handled", code)
+	}
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import "strings"
+
+// Scope is the event a webhook is for, e.g. store/order/created. Scopes ending in /* match every event below them
+type Scope string
+
+// Order scopes
+const (
+	ScopeOrderAll                Scope = "store/order/*"
+	ScopeOrderCreated            Scope = "store/order/created"
+	ScopeOrderUpdated            Scope = "store/order/updated"
+	ScopeOrderArchived           Scope = "store/order/archived"
+	ScopeOrderStatusUpdated      Scope = "store/order/statusUpdated"
+	ScopeOrderMessageCreated     Scope = "store/order/message/created"
+	ScopeOrderRefundCreated      Scope = "store/order/refund/created"
+	ScopeOrderTransactionCreated Scope = "store/order/transaction/created"
+)
+
+// Product scopes, variants are reported as skus
+const (
+	ScopeProductAll                   Scope = "store/product/*"
+	ScopeProductCreated               Scope = "store/product/created"
+	ScopeProductUpdated               Scope = "store/product/updated"
+	ScopeProductDeleted               Scope = "store/product/deleted"
+	ScopeProductInventoryUpdated      Scope = "store/product/inventory/updated"
+	ScopeProductInventoryOrderUpdated Scope = "store/product/inventory/order/updated"
+	ScopeSKUAll                       Scope = "store/sku/*"
+	ScopeSKUCreated                   Scope = "store/sku/created"
+	ScopeSKUUpdated                   Scope = "store/sku/updated"
+	ScopeSKUDeleted                   Scope = "store/sku/deleted"
+	ScopeSKUInventoryUpdated          Scope = "store/sku/inventory/updated"
+	ScopeSKUInventoryOrderUpdated     Scope = "store/sku/inventory/order/updated"
+)
+
+// Customer scopes
+const (
+	ScopeCustomerAll                      Scope = "store/customer/*"
+	ScopeCustomerCreated                  Scope = "store/customer/created"
+	ScopeCustomerUpdated                  Scope = "store/customer/updated"
+	ScopeCustomerDeleted                  Scope = "store/customer/deleted"
+	ScopeCustomerAddressCreated           Scope = "store/customer/address/created"
+	ScopeCustomerAddressUpdated           Scope = "store/customer/address/updated"
+	ScopeCustomerAddressDeleted           Scope = "store/customer/address/deleted"
+	ScopeCustomerPaymentInstrumentUpdated Scope = "store/customer/payment/instrument/default/updated"
+)
+
+// Cart scopes
+const (
+	ScopeCartAll             Scope = "store/cart/*"
+	ScopeCartCreated         Scope = "store/cart/created"
+	ScopeCartUpdated         Scope = "store/cart/updated"
+	ScopeCartDeleted         Scope = "store/cart/deleted"
+	ScopeCartCouponApplied   Scope = "store/cart/couponApplied"
+	ScopeCartAbandoned       Scope = "store/cart/abandoned"
+	ScopeCartConverted       Scope = "store/cart/converted"
+	ScopeCartLineItemAll     Scope = "store/cart/lineItem/*"
+	ScopeCartLineItemCreated Scope = "store/cart/lineItem/created"
+	ScopeCartLineItemUpdated Scope = "store/cart/lineItem/updated"
+	ScopeCartLineItemDeleted Scope = "store/cart/lineItem/deleted"
+)
+
+// Shipment scopes
+const (
+	ScopeShipmentAll     Scope = "store/shipment/*"
+	ScopeShipmentCreated Scope = "store/shipment/created"
+	ScopeShipmentUpdated Scope = "store/shipment/updated"
+	ScopeShipmentDeleted Scope = "store/shipment/deleted"
+)
+
+// Matches reports whether an event with scope other is covered by s, either the same scope or one below a wildcard
+func (s Scope) Matches(other Scope) bool {
+	if s == other {
+		return true
+	}
+	if !strings.HasSuffix(string(s), "/*") {
+		return false
+	}
+	return strings.HasPrefix(string(other), strings.TrimSuffix(string(s), "*"))
+}